@@ -0,0 +1,188 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/up-sdk-go"
+
+	uphttp "github.com/upbound/up/internal/http"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	errFmtInvalidHeader = "invalid header %q, must be of the form 'Key: Value'"
+	errReadRequestBody  = "unable to read request body"
+	errInvalidPath      = "invalid API path"
+	errRequestFailed    = "API request failed"
+)
+
+// AfterApply constructs an HTTP client that is authenticated with the current
+// profile.
+func (c *apiCmd) AfterApply(kongCtx *kong.Context) error {
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	cfg, err := upCtx.BuildSDKConfig()
+	if err != nil {
+		return err
+	}
+	// BuildSDKConfig always constructs an HTTPClient, which carries the
+	// session cookie and transport of the profile.
+	hc, ok := cfg.Client.(*up.HTTPClient)
+	if !ok {
+		return errors.New(errRequestFailed)
+	}
+	c.client = hc.HTTP
+	c.baseURL = upCtx.APIEndpoint
+	c.stdin = os.Stdin
+	return nil
+}
+
+// apiCmd performs an authenticated request against the Upbound API.
+type apiCmd struct {
+	client  uphttp.Client
+	baseURL *url.URL
+	stdin   io.Reader
+
+	Path   string   `arg:"" help:"API path to request, e.g. /v1/organizations."`
+	Method string   `short:"X" default:"GET" help:"HTTP method of the request."`
+	Body   string   `help:"Request body. Use @file to read it from a file, or @- to read it from stdin."`
+	Header []string `short:"H" help:"Additional request header of the form 'Key: Value'. May be repeated."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+// Mutating returns true if the request may change state. Such requests are
+// refused in read-only mode and recorded in the audit log.
+func (c *apiCmd) Mutating() bool {
+	return !isSafeMethod(c.Method)
+}
+
+func (c *apiCmd) Help() string {
+	return `
+The api command performs an authenticated request against the Upbound API
+using the credentials of the current profile and prints the response. JSON
+responses are indented. It can be used to access API features that are not
+yet available as dedicated commands.
+
+Examples:
+
+    up api /v1/organizations
+    up api /v1/organizations --method POST --body @org.json
+`
+}
+
+// Run executes the api command.
+func (c *apiCmd) Run(kongCtx *kong.Context) error {
+	body, err := c.readBody()
+	if err != nil {
+		return errors.Wrap(err, errReadRequestBody)
+	}
+	u, err := c.baseURL.Parse(c.Path)
+	if err != nil {
+		return errors.Wrap(err, errInvalidPath)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(c.Method), u.String(), body)
+	if err != nil {
+		return errors.Wrap(err, errRequestFailed)
+	}
+	req.Header.Set("User-Agent", upbound.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, h := range c.Header {
+		k, v, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(k) == "" {
+			return errors.Errorf(errFmtInvalidHeader, h)
+		}
+		req.Header.Set(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errRequestFailed)
+	}
+	defer res.Body.Close() // nolint:errcheck
+	if err := (&up.DefaultErrorHandler{}).Handle(res); err != nil {
+		return errors.Wrap(err, errRequestFailed)
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, errRequestFailed)
+	}
+	return writeResponse(kongCtx.Stdout, b)
+}
+
+// readBody returns the request body, or nil if none was supplied.
+func (c *apiCmd) readBody() (io.Reader, error) {
+	switch {
+	case c.Body == "":
+		return nil, nil
+	case c.Body == "@-":
+		b, err := io.ReadAll(c.stdin)
+		return bytes.NewReader(b), err
+	case strings.HasPrefix(c.Body, "@"):
+		b, err := os.ReadFile(filepath.Clean(strings.TrimPrefix(c.Body, "@")))
+		return bytes.NewReader(b), err
+	default:
+		return strings.NewReader(c.Body), nil
+	}
+}
+
+// writeResponse writes the response body, indenting it if it is JSON.
+func writeResponse(w io.Writer, b []byte) error {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	out := &bytes.Buffer{}
+	if err := json.Indent(out, b, "", "  "); err != nil {
+		out.Reset()
+		out.Write(b)
+	}
+	if !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// isSafeMethod returns true if requests with the supplied HTTP method do not
+// change state.
+func isSafeMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
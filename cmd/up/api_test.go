@@ -0,0 +1,119 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/upbound/up/internal/http/mocks"
+)
+
+func TestAPIRun(t *testing.T) {
+	errBoom := errors.New("boom")
+	baseURL, _ := url.Parse("https://api.test.com")
+
+	respond := func(status int, body string) func(*http.Request) (*http.Response, error) {
+		return func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		cmd    *apiCmd
+		want   string
+		err    error
+	}{
+		"ErrRequestFailed": {
+			reason: "An error performing the request should be returned.",
+			cmd: &apiCmd{
+				client: &mocks.MockClient{DoFn: func(*http.Request) (*http.Response, error) { return nil, errBoom }},
+				Path:   "/v1/organizations",
+				Method: http.MethodGet,
+			},
+			err: errors.Wrap(errBoom, errRequestFailed),
+		},
+		"ErrInvalidHeader": {
+			reason: "A header that is not of the form 'Key: Value' should be rejected.",
+			cmd: &apiCmd{
+				Path:   "/v1/organizations",
+				Method: http.MethodGet,
+				Header: []string{"bad"},
+			},
+			err: errors.Errorf(errFmtInvalidHeader, "bad"),
+		},
+		"SuccessIndentJSON": {
+			reason: "A JSON response should be printed indented.",
+			cmd: &apiCmd{
+				client: &mocks.MockClient{DoFn: func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodPost || req.URL.String() != "https://api.test.com/v1/organizations" {
+						return nil, errBoom
+					}
+					b, _ := io.ReadAll(req.Body)
+					if string(b) != `{"name":"cool-org"}` || req.Header.Get("X-Cool") != "yes" {
+						return nil, errBoom
+					}
+					return respond(http.StatusOK, `{"id":1}`)(req)
+				}},
+				Path:   "/v1/organizations",
+				Method: "post",
+				Body:   "@-",
+				Header: []string{"X-Cool: yes"},
+				stdin:  strings.NewReader(`{"name":"cool-org"}`),
+			},
+			want: "{\n  \"id\": 1\n}\n",
+		},
+		"SuccessRaw": {
+			reason: "A non-JSON response should be printed as is.",
+			cmd: &apiCmd{
+				client: &mocks.MockClient{DoFn: respond(http.StatusOK, "cool")},
+				Path:   "/v1/cool",
+				Method: http.MethodGet,
+			},
+			want: "cool\n",
+		},
+		"SuccessEmpty": {
+			reason: "An empty response should print nothing.",
+			cmd: &apiCmd{
+				client: &mocks.MockClient{DoFn: respond(http.StatusNoContent, "")},
+				Path:   "/v1/cool",
+				Method: http.MethodDelete,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.cmd.baseURL = baseURL
+			out := &bytes.Buffer{}
+			err := tc.cmd.Run(&kong.Context{Kong: &kong.Kong{Stdout: out}})
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
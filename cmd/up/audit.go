@@ -0,0 +1,72 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/upbound/up/internal/audit"
+	"github.com/upbound/up/internal/feature"
+)
+
+// recordAudit records the command run in the supplied context, and its
+// result, in the audit log if the command is mutating. Failing to record is
+// reported but does not fail the command.
+func recordAudit(ctx *kong.Context, runErr error) {
+	cmd, target, params := invocation(ctx)
+	if !feature.IsMutating(ctx) {
+		return
+	}
+	e := audit.Entry{
+		Time:       time.Now().UTC(),
+		Command:    strings.Join(cmd, " "),
+		Target:     target,
+		Parameters: params,
+		Result:     audit.ResultSuccess,
+	}
+	if runErr != nil {
+		e.Result = audit.ResultFailure
+		e.Error = runErr.Error()
+	}
+	path, err := audit.DefaultPath()
+	if err == nil {
+		err = audit.NewLog(path).Record(e)
+	}
+	if err != nil {
+		fmt.Fprintf(ctx.Stderr, "warning: %s\n", err)
+	}
+}
+
+// invocation returns the subcommand names, positional arguments and flags of
+// the command run in the supplied context. The values of sensitive flags are
+// redacted.
+func invocation(ctx *kong.Context) (cmd, args []string, flags map[string]string) {
+	cmd, flags = []string{}, map[string]string{}
+	for _, p := range ctx.Path {
+		switch {
+		case p.Command != nil:
+			cmd = append(cmd, p.Command.Name)
+		case p.Positional != nil:
+			args = append(args, fmt.Sprint(ctx.Value(p).Interface()))
+		case p.Flag != nil && p.Flag.Name != "audit-log":
+			flags[p.Flag.Name] = audit.Redact(p.Flag.Name, fmt.Sprint(ctx.FlagValue(p.Flag)))
+		}
+	}
+	return cmd, args, flags
+}
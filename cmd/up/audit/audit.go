@@ -0,0 +1,81 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/audit"
+	"github.com/upbound/up/internal/upterm"
+)
+
+// Cmd contains commands for inspecting the local audit log.
+type Cmd struct {
+	Log logCmd `cmd:"" help:"Inspect the audit log of mutating commands."`
+}
+
+type logCmd struct {
+	Show showCmd `cmd:"" help:"Show the entries of the audit log."`
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *showCmd) AfterApply() error {
+	if c.Path != "" {
+		return nil
+	}
+	p, err := audit.DefaultPath()
+	if err != nil {
+		return err
+	}
+	c.Path = p
+	return nil
+}
+
+// showCmd shows the entries of the audit log.
+type showCmd struct {
+	Since time.Duration `default:"24h" help:"Show entries recorded within the given duration."`
+	Path  string        `type:"path" help:"Path of the audit log. Defaults to the log in the up config directory."`
+}
+
+func (c *showCmd) Help() string {
+	return `
+Mutating commands, such as create, delete, import and push, are recorded in a
+local audit log when the --audit-log flag or the UP_AUDIT_LOG environment
+variable is set. The values of sensitive flags, such as tokens and secrets, are
+never recorded.`
+}
+
+var fieldNames = []string{"TIME", "COMMAND", "TARGET", "RESULT", "ERROR"}
+
+// Run executes the show command.
+func (c *showCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	entries, err := audit.NewLog(c.Path).Read(time.Now().Add(-c.Since))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		p.Printfln("No entries recorded in the last %s", c.Since)
+		return nil
+	}
+	return printer.Print(entries, fieldNames, extractFields)
+}
+
+func extractFields(obj any) []string {
+	e := obj.(audit.Entry)
+	return []string{e.Time.Local().Format(time.RFC3339), e.Command, strings.Join(e.Target, " "), e.Result, e.Error}
+}
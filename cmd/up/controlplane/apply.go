@@ -0,0 +1,291 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/pointer"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	fieldManager      = "up"
+	readyPollInterval = 5 * time.Second
+	defaultApplyOrder = 10
+
+	errFmtReadManifest   = "unable to read manifest %s"
+	errFmtDecodeManifest = "unable to decode manifest %s"
+	errNoManifests       = "no manifests found"
+	errFmtApplyObject    = "unable to apply %s %s"
+	errNotReady          = "not all applied objects became ready"
+)
+
+// applyOrder is the order kinds are applied in, so that the types and
+// packages other objects depend on exist first. Other kinds are applied last.
+var applyOrder = map[string]int{
+	"Namespace":                   1,
+	"CustomResourceDefinition":    2,
+	"DeploymentRuntimeConfig":     3,
+	"ControllerConfig":            3,
+	"Provider":                    4,
+	"Function":                    4,
+	"Configuration":               4,
+	"CompositeResourceDefinition": 5,
+	"Composition":                 6,
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *applyCmd) AfterApply(upCtx *upbound.Context) error {
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.ControlPlane), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	mapper, err := kube.NewDiscoveryRESTMapper(cfg)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
+	c.mapper = mapper
+	return nil
+}
+
+// applyCmd applies local manifests to a control plane.
+type applyCmd struct {
+	dClient dynamic.Interface
+	mapper  meta.RESTMapper
+
+	File         []string      `short:"f" required:"" type:"path" help:"Manifest file or directory of manifests to apply. May be repeated."`
+	ControlPlane string        `name:"controlplane" required:"" help:"Name of the control plane to apply to." predictor:"ctps"`
+	Token        string        `required:"" help:"API token used to authenticate."`
+	WaitReady    time.Duration `help:"Wait up to the given duration for the applied objects to become ready."`
+}
+
+func (c *applyCmd) Help() string {
+	return `
+The apply command applies local manifests, such as claims, XRDs and packages,
+to a control plane using server-side apply. Directories are read recursively
+for .yaml, .yml and .json files. Namespaces, CRDs, packages, XRDs and
+Compositions are applied before other objects.
+
+With --wait-ready the command waits for the applied objects to report that
+they are ready, installed and healthy, or established.`
+}
+
+type applyResult struct {
+	obj   *unstructured.Unstructured
+	ready bool
+}
+
+var applyFieldNames = []string{"KIND", "NAMESPACE", "NAME", "STATUS"}
+
+// Run executes the apply command.
+func (c *applyCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error { //nolint:gocyclo
+	objs, err := readManifests(c.File)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		return kindOrder(objs[i].GetKind()) < kindOrder(objs[j].GetKind())
+	})
+
+	ctx := context.Background()
+	results := make([]*applyResult, len(objs))
+	for i, u := range objs {
+		applied, err := c.apply(ctx, u)
+		if err != nil {
+			return errors.Wrapf(err, errFmtApplyObject, u.GetKind(), u.GetName())
+		}
+		results[i] = &applyResult{obj: applied}
+	}
+
+	if c.WaitReady > 0 {
+		wait := func() error {
+			return c.waitReady(ctx, results)
+		}
+		err = upterm.WrapWithSuccessSpinner("Waiting for objects to become ready", upterm.CheckmarkSuccessSpinner, wait)
+	}
+	if perr := printer.Print(results, applyFieldNames, c.extractApplyFields); perr != nil {
+		return perr
+	}
+	return err
+}
+
+func (c *applyCmd) apply(ctx context.Context, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ri, err := c.resourceFor(u)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(u.Object)
+	if err != nil {
+		return nil, err
+	}
+	return ri.Patch(ctx, u.GetName(), types.ApplyPatchType, b, metav1.PatchOptions{FieldManager: fieldManager, Force: pointer.Bool(true)})
+}
+
+// resourceFor returns the dynamic client for the type of the supplied object.
+// Discovery is refreshed once if the type is unknown, as it may have been
+// defined by an object applied earlier.
+func (c *applyCmd) resourceFor(u *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := u.GroupVersionKind()
+	m, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		if r, ok := c.mapper.(meta.ResettableRESTMapper); ok {
+			r.Reset()
+			m, err = c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := u.GetNamespace()
+		if ns == "" {
+			ns = metav1.NamespaceDefault
+			u.SetNamespace(ns)
+		}
+		return c.dClient.Resource(m.Resource).Namespace(ns), nil
+	}
+	return c.dClient.Resource(m.Resource), nil
+}
+
+func (c *applyCmd) waitReady(ctx context.Context, results []*applyResult) error {
+	err := wait.PollUntilContextTimeout(ctx, readyPollInterval, c.WaitReady, true, func(ctx context.Context) (bool, error) {
+		done := true
+		for _, r := range results {
+			if r.ready {
+				continue
+			}
+			ri, err := c.resourceFor(r.obj)
+			if err != nil {
+				return false, err
+			}
+			u, err := ri.Get(ctx, r.obj.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			r.obj, r.ready = u, kube.IsReady(u)
+			done = done && r.ready
+		}
+		return done, nil
+	})
+	return errors.Wrap(err, errNotReady)
+}
+
+func (c *applyCmd) extractApplyFields(obj any) []string {
+	r := obj.(*applyResult)
+	status := "applied"
+	if c.WaitReady > 0 {
+		status = "not ready"
+		if r.ready {
+			status = "ready"
+		}
+	}
+	return []string{r.obj.GetKind(), r.obj.GetNamespace(), r.obj.GetName(), status}
+}
+
+// readManifests reads the objects in the supplied files and directories.
+func readManifests(paths []string) ([]*unstructured.Unstructured, error) {
+	files := []string{}
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(f string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(f)) {
+			case ".yaml", ".yml", ".json":
+				files = append(files, f)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtReadManifest, p)
+		}
+	}
+
+	objs := []*unstructured.Unstructured{}
+	for _, f := range files {
+		o, err := decodeManifest(f)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, o...)
+	}
+	if len(objs) == 0 {
+		return nil, errors.New(errNoManifests)
+	}
+	return objs, nil
+}
+
+func decodeManifest(file string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtReadManifest, file)
+	}
+	defer f.Close() //nolint:errcheck,gosec
+
+	objs := []*unstructured.Unstructured{}
+	d := kyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := d.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrapf(err, errFmtDecodeManifest, file)
+		}
+		// Skip empty documents.
+		if len(u.Object) == 0 {
+			continue
+		}
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
+
+func kindOrder(kind string) int {
+	if o, ok := applyOrder[kind]; ok {
+		return o
+	}
+	return defaultApplyOrder
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	apixv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/install"
+	"github.com/upbound/up/internal/install/helm"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	fluxChartName = "flux2"
+	fluxNamespace = "flux-system"
+
+	gitRepositoryCRD = "gitrepositories.source.toolkit.fluxcd.io"
+
+	errGetFluxCRD      = "failed to check whether flux is installed"
+	errCreateNamespace = "failed to create flux namespace"
+	errInstallFlux     = "failed to install flux"
+	errGetSecret       = "failed to get git credentials secret"
+	errFmtApply        = "failed to apply %s"
+)
+
+var (
+	fluxChartURL, _ = url.Parse("https://fluxcd-community.github.io/helm-charts")
+
+	// Only the controllers needed to sync from git are installed.
+	fluxValues = map[string]any{
+		"sourceController":          map[string]any{"create": true},
+		"kustomizeController":       map[string]any{"create": true},
+		"helmController":            map[string]any{"create": false},
+		"imageAutomationController": map[string]any{"create": false},
+		"imageReflectionController": map[string]any{"create": false},
+		"notificationController":    map[string]any{"create": false},
+		"policies":                  map[string]any{"create": false},
+	}
+
+	gitRepositoryGVR = schema.GroupVersionResource{
+		Group:    "source.toolkit.fluxcd.io",
+		Version:  "v1",
+		Resource: "gitrepositories",
+	}
+	kustomizationGVR = schema.GroupVersionResource{
+		Group:    "kustomize.toolkit.fluxcd.io",
+		Version:  "v1",
+		Resource: "kustomizations",
+	}
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *gitCmd) AfterApply(upCtx *upbound.Context) error {
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.Name), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	crdClient, err := apixv1client.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	mgr, err := helm.NewManager(cfg,
+		fluxChartName,
+		fluxChartURL,
+		helm.WithNamespace(fluxNamespace),
+		helm.Wait(),
+	)
+	if err != nil {
+		return err
+	}
+	c.kClient = kClient
+	c.dClient = dClient
+	c.crdClient = crdClient
+	c.mgr = mgr
+	return nil
+}
+
+// gitCmd configures a control plane to continuously reconcile its packages
+// and claims from a git repository.
+type gitCmd struct {
+	kClient   kubernetes.Interface
+	dClient   dynamic.Interface
+	crdClient apixv1client.ApiextensionsV1Interface
+	mgr       install.Manager
+
+	Name string `arg:"" required:"" help:"Name of control plane." predictor:"ctps"`
+
+	Token    string        `required:"" help:"API token used to authenticate."`
+	Repo     string        `required:"" help:"URL of the git repository."`
+	Branch   string        `default:"main" help:"Branch of the git repository to reconcile from."`
+	Path     string        `default:"./" help:"Path within the git repository containing the manifests to reconcile."`
+	Secret   string        `help:"Name of a secret in the flux-system namespace holding the credentials of the git repository."`
+	Interval time.Duration `default:"1m" help:"Interval at which the git repository is reconciled."`
+	Source   string        `default:"upbound-git" help:"Name of the git source created in the control plane."`
+
+	FluxVersion string `hidden:"" default:"2.10.2" help:"Version of the flux2 chart to install."`
+}
+
+func (c *gitCmd) Help() string {
+	return `
+The git command bootstraps GitOps for a control plane. It installs the Flux
+source and kustomize controllers into the control plane if they are not
+present, and configures them to continuously reconcile the manifests at the
+given path of a git repository, such as packages and claims.
+
+Credentials for private repositories must be stored in a secret in the
+flux-system namespace of the control plane and referenced with --secret.`
+}
+
+// Run executes the git command.
+func (c *gitCmd) Run(p pterm.TextPrinter) error {
+	ctx := context.Background()
+	if err := c.installFlux(ctx, p); err != nil {
+		return err
+	}
+	if c.Secret != "" {
+		if _, err := c.kClient.CoreV1().Secrets(fluxNamespace).Get(ctx, c.Secret, metav1.GetOptions{}); err != nil {
+			return errors.Wrap(err, errGetSecret)
+		}
+	}
+
+	repo := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"url":      c.Repo,
+			"interval": c.Interval.String(),
+			"ref":      map[string]any{"branch": c.Branch},
+		},
+	}}
+	if c.Secret != "" {
+		_ = unstructured.SetNestedField(repo.Object, c.Secret, "spec", "secretRef", "name")
+	}
+	if err := c.apply(ctx, gitRepositoryGVR, "GitRepository", repo); err != nil {
+		return err
+	}
+
+	ks := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"interval": c.Interval.String(),
+			"path":     c.Path,
+			"prune":    true,
+			"sourceRef": map[string]any{
+				"kind": "GitRepository",
+				"name": c.Source,
+			},
+		},
+	}}
+	if err := c.apply(ctx, kustomizationGVR, "Kustomization", ks); err != nil {
+		return err
+	}
+
+	p.Printfln("%s configured to reconcile %s from %s", c.Name, c.Path, c.Repo)
+	return nil
+}
+
+func (c *gitCmd) installFlux(ctx context.Context, p pterm.TextPrinter) error {
+	_, err := c.crdClient.CustomResourceDefinitions().Get(ctx, gitRepositoryCRD, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, errGetFluxCRD)
+	}
+	_, err = c.kClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: fluxNamespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, errCreateNamespace)
+	}
+	p.Printfln("Installing flux %s", c.FluxVersion)
+	return errors.Wrap(c.mgr.Install(c.FluxVersion, fluxValues), errInstallFlux)
+}
+
+// apply creates the supplied object, or updates its spec if it exists.
+func (c *gitCmd) apply(ctx context.Context, gvr schema.GroupVersionResource, kind string, u *unstructured.Unstructured) error {
+	u.SetAPIVersion(gvr.GroupVersion().String())
+	u.SetKind(kind)
+	u.SetName(c.Source)
+	u.SetNamespace(fluxNamespace)
+
+	ri := c.dClient.Resource(gvr).Namespace(fluxNamespace)
+	cur, err := ri.Get(ctx, c.Source, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		_, err = ri.Create(ctx, u, metav1.CreateOptions{})
+		return errors.Wrapf(err, errFmtApply, kind)
+	}
+	if err != nil {
+		return errors.Wrapf(err, errFmtApply, kind)
+	}
+	cur.Object["spec"] = u.Object["spec"]
+	_, err = ri.Update(ctx, cur, metav1.UpdateOptions{})
+	return errors.Wrapf(err, errFmtApply, kind)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/upbound/up-sdk-go/service/configurations"
 	cp "github.com/upbound/up-sdk-go/service/controlplanes"
+	"github.com/upbound/up/cmd/up/controlplane/configure"
 	"github.com/upbound/up/cmd/up/controlplane/kubeconfig"
 	"github.com/upbound/up/cmd/up/controlplane/pkg"
 	"github.com/upbound/up/cmd/up/controlplane/pullsecret"
@@ -86,10 +87,18 @@ func PredictControlPlanes() complete.Predictor {
 
 // Cmd contains commands for interacting with control planes.
 type Cmd struct {
-	Create createCmd `cmd:"" help:"Create a managed control plane."`
-	Delete deleteCmd `cmd:"" help:"Delete a control plane."`
-	List   listCmd   `cmd:"" help:"List control planes for the account."`
-	Get    getCmd    `cmd:"" help:"Get a single control plane."`
+	Create  createCmd  `cmd:"" help:"Create a managed control plane."`
+	Delete  deleteCmd  `cmd:"" help:"Delete a control plane."`
+	List    listCmd    `cmd:"" help:"List control planes for the account."`
+	Get     getCmd     `cmd:"" help:"Get a single control plane."`
+	Events  eventsCmd  `cmd:"" help:"Show events from inside a control plane."`
+	Token   tokenCmd   `cmd:"" help:"Mint a short-lived token scoped to a control plane."`
+	Restart restartCmd `cmd:"" help:"Restart Crossplane or provider deployments inside a control plane."`
+	Apply   applyCmd   `cmd:"" help:"Apply manifests to a control plane."`
+	Diff    diffCmd    `cmd:"" help:"Compare the Crossplane state of two control planes."`
+	Wait    waitCmd    `cmd:"" help:"Wait for a control plane to meet a condition."`
+	Exec    execCmd    `cmd:"" help:"Run a command against many control planes of a Space."`
+	Dev     devCmd     `cmd:"" maturity:"alpha" mutating:"" help:"Run a disposable local control plane for development."`
 
 	Connect connectCmd `cmd:"" help:"Connect an App Cluster to a managed control plane."`
 
@@ -100,6 +109,8 @@ type Cmd struct {
 
 	Kubeconfig kubeconfig.Cmd `cmd:"" name:"kubeconfig" help:"Manage control plane kubeconfig data."`
 
+	Configure configure.Cmd `cmd:"" help:"Configure a control plane."`
+
 	// Common Upbound API configuration
 	Flags upbound.Flags `embed:""`
 }
@@ -16,25 +16,97 @@ package controlplane
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
 
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pterm/pterm"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/pointer"
 
+	uerrors "github.com/upbound/up-sdk-go/errors"
 	"github.com/upbound/up-sdk-go/service/configurations"
 	cp "github.com/upbound/up-sdk-go/service/controlplanes"
 
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/resources"
 	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+	"github.com/upbound/up/internal/xpkg"
 )
 
+const (
+	errPackagesTokenRequired = "--token is required to install packages"
+	errFmtNotReady           = "control plane %s did not become ready within %s"
+	errFmtInstallPackage     = "unable to install %s %s"
+	errFmtNotHealthy         = "packages did not become healthy within %s"
+	errFmtRetryTimeout       = "did not succeed within %s"
+)
+
+// bootstrapPackage is a package installed into a newly created control plane.
+type bootstrapPackage struct {
+	gvr schema.GroupVersionResource
+	obj *unstructured.Unstructured
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *createCmd) AfterApply() error {
+	if c.hasPackages() && c.Token == "" {
+		return errors.New(errPackagesTokenRequired)
+	}
+	return nil
+}
+
 // createCmd creates a control plane on Upbound.
 type createCmd struct {
 	Name string `arg:"" required:"" help:"Name of control plane."`
 
 	ConfigurationName string `required:"" help:"The name of the Configuration."`
 	Description       string `short:"d" help:"Description for control plane."`
+
+	WithProvider      []string      `name:"with-provider" help:"Provider package to install once the control plane is ready. May be repeated."`
+	WithConfiguration []string      `name:"with-configuration" help:"Configuration package to install once the control plane is ready. May be repeated."`
+	WithFunction      []string      `name:"with-function" help:"Function package to install once the control plane is ready. May be repeated."`
+	Token             string        `help:"API token used to authenticate. Required to install packages."`
+	Timeout           time.Duration `default:"10m" help:"How long to wait for the control plane to become ready, and for its packages to become healthy."`
+}
+
+func (c *createCmd) Help() string {
+	return `
+The create command creates a managed control plane.
+
+Packages supplied with --with-provider, --with-configuration and
+--with-function are installed once the control plane is ready, and the command
+waits for them to become healthy. Each of these flags may be repeated, e.g.
+
+  up ctp create my-ctp --configuration-name=my-config --token=$TOKEN \
+    --with-provider=xpkg.upbound.io/upbound/provider-aws-s3:v0.47.0 \
+    --with-function=xpkg.upbound.io/crossplane-contrib/function-patch-and-transform:v0.2.1
+
+Packages are named after their repository, as with the install command.`
 }
 
 // Run executes the create command.
 func (c *createCmd) Run(p pterm.TextPrinter, cc *cp.Client, cfc *configurations.Client, upCtx *upbound.Context) error {
+	// Parse packages before creating the control plane, so that invalid
+	// references do not leave a control plane without its packages behind.
+	pkgs, err := c.packages(upCtx.RegistryEndpoint.Hostname())
+	if err != nil {
+		return err
+	}
+
 	// Get the UUID from the Configuration name, if it exists.
 	cfg, err := cfc.Get(context.Background(), upCtx.Account, c.ConfigurationName)
 	if err != nil {
@@ -50,5 +122,159 @@ func (c *createCmd) Run(p pterm.TextPrinter, cc *cp.Client, cfc *configurations.
 	}
 
 	p.Printfln("%s created", c.Name)
-	return nil
+	if len(pkgs) == 0 {
+		return nil
+	}
+	return c.bootstrap(p, cc, upCtx, pkgs)
+}
+
+func (c *createCmd) hasPackages() bool {
+	return len(c.WithProvider)+len(c.WithConfiguration)+len(c.WithFunction) > 0
+}
+
+// packages returns the packages to install into the control plane.
+func (c *createCmd) packages(registry string) ([]bootstrapPackage, error) {
+	kinds := []struct {
+		apiVersion string
+		kind       string
+		resource   string
+		refs       []string
+	}{
+		{"pkg.crossplane.io/v1", "Provider", "providers", c.WithProvider},
+		{"pkg.crossplane.io/v1", "Configuration", "configurations", c.WithConfiguration},
+		{"pkg.crossplane.io/v1beta1", "Function", "functions", c.WithFunction},
+	}
+	pkgs := []bootstrapPackage{}
+	for _, k := range kinds {
+		gv, err := schema.ParseGroupVersion(k.apiVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range k.refs {
+			ref, err := name.ParseReference(r, name.WithDefaultRegistry(registry))
+			if err != nil {
+				return nil, err
+			}
+			pkgs = append(pkgs, bootstrapPackage{
+				gvr: gv.WithResource(k.resource),
+				obj: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": k.apiVersion,
+					"kind":       k.kind,
+					"metadata": map[string]any{
+						"name": xpkg.ToDNSLabel(ref.Context().RepositoryStr()),
+					},
+					"spec": map[string]any{
+						"package": ref.Name(),
+					},
+				}},
+			})
+		}
+	}
+	return pkgs, nil
+}
+
+// bootstrap waits for the control plane to become ready, installs the
+// supplied packages and waits for them to become healthy. Transient errors,
+// e.g. because the package types are not served yet right after the control
+// plane became ready, are retried until the timeout expires.
+func (c *createCmd) bootstrap(p pterm.TextPrinter, cc *cp.Client, upCtx *upbound.Context, pkgs []bootstrapPackage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	waitReady := func() error {
+		return pollTransient(ctx, fmt.Sprintf(errFmtNotReady, c.Name, c.Timeout), func(ctx context.Context) (bool, error) {
+			ctp, err := cc.Get(ctx, upCtx.Account, c.Name)
+			if err != nil {
+				return false, err
+			}
+			return ctp.Status == cp.StatusReady, nil
+		})
+	}
+	if err := upterm.WrapWithSuccessSpinner(fmt.Sprintf("Waiting for %s to become ready", c.Name), upterm.CheckmarkSuccessSpinner, waitReady); err != nil {
+		return err
+	}
+
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.Name), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		b, err := json.Marshal(pkg.obj.Object)
+		if err != nil {
+			return err
+		}
+		err = pollTransient(ctx, fmt.Sprintf(errFmtRetryTimeout, c.Timeout), func(ctx context.Context) (bool, error) {
+			_, err := dClient.Resource(pkg.gvr).Patch(ctx, pkg.obj.GetName(), types.ApplyPatchType, b, metav1.PatchOptions{FieldManager: fieldManager, Force: pointer.Bool(true)})
+			return err == nil, err
+		})
+		if err != nil {
+			return errors.Wrapf(err, errFmtInstallPackage, pkg.obj.GetKind(), pkg.obj.GetName())
+		}
+		p.Printfln("%s %s installed", pkg.obj.GetKind(), pkg.obj.GetName())
+	}
+
+	waitHealthy := func() error {
+		healthy := make([]bool, len(pkgs))
+		return pollTransient(ctx, fmt.Sprintf(errFmtNotHealthy, c.Timeout), func(ctx context.Context) (bool, error) {
+			done := true
+			for i, pkg := range pkgs {
+				if healthy[i] {
+					continue
+				}
+				u, err := dClient.Resource(pkg.gvr).Get(ctx, pkg.obj.GetName(), metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				rp := resources.Package{Unstructured: *u}
+				healthy[i] = rp.GetInstalled() && rp.GetHealthy()
+				done = done && healthy[i]
+			}
+			return done, nil
+		})
+	}
+	return upterm.WrapWithSuccessSpinner("Waiting for packages to become healthy", upterm.CheckmarkSuccessSpinner, waitHealthy)
+}
+
+// pollTransient calls the supplied function until it returns true or a
+// non-transient error, or until the supplied context is done. If the context
+// is done an error with the supplied message is returned, wrapping the last
+// transient error, if any.
+func pollTransient(ctx context.Context, msg string, f func(ctx context.Context) (bool, error)) error {
+	var last error
+	err := wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		done, err := f(ctx)
+		if err != nil && isTransient(err) {
+			last = err
+			return false, nil
+		}
+		return done, err
+	})
+	if !wait.Interrupted(err) {
+		return err
+	}
+	if last != nil {
+		return errors.Wrap(last, msg)
+	}
+	return errors.New(msg)
+}
+
+// isTransient returns true if the supplied error may not occur again when the
+// request is retried.
+func isTransient(err error) bool {
+	var ue *uerrors.Error
+	if errors.As(err, &ue) {
+		return ue.Status == http.StatusTooManyRequests || ue.Status >= http.StatusInternalServerError
+	}
+	return meta.IsNoMatchError(err) ||
+		kerrors.IsNotFound(err) ||
+		kerrors.IsTooManyRequests(err) ||
+		kerrors.IsServiceUnavailable(err) ||
+		kerrors.IsServerTimeout(err) ||
+		kerrors.IsTimeout(err) ||
+		kerrors.IsInternalError(err) ||
+		feature.IsNetworkError(err)
 }
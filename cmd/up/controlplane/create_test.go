@@ -0,0 +1,154 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	uerrors "github.com/upbound/up-sdk-go/errors"
+)
+
+func TestCreatePackages(t *testing.T) {
+	_, errInvalidRef := name.ParseReference("Provider AWS", name.WithDefaultRegistry("xpkg.upbound.io"))
+	pkg := func(apiVersion, kind, resource, name, ref string) bootstrapPackage {
+		gv, _ := schema.ParseGroupVersion(apiVersion)
+		return bootstrapPackage{
+			gvr: gv.WithResource(resource),
+			obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": apiVersion,
+				"kind":       kind,
+				"metadata":   map[string]any{"name": name},
+				"spec":       map[string]any{"package": ref},
+			}},
+		}
+	}
+
+	type want struct {
+		pkgs []bootstrapPackage
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		cmd    *createCmd
+		want   want
+	}{
+		"NoPackages": {
+			reason: "No packages should be installed if none are supplied.",
+			cmd:    &createCmd{},
+			want:   want{pkgs: []bootstrapPackage{}},
+		},
+		"AllKinds": {
+			reason: "Packages of each kind should be named after their repository and default to the Upbound registry.",
+			cmd: &createCmd{
+				WithProvider:      []string{"upbound/provider-aws-s3:v0.47.0", "ghcr.io/example/provider-foo:v1.0.0"},
+				WithConfiguration: []string{"upbound/platform-ref-aws:v0.9.0"},
+				WithFunction:      []string{"crossplane-contrib/function-patch-and-transform:v0.2.1"},
+			},
+			want: want{pkgs: []bootstrapPackage{
+				pkg("pkg.crossplane.io/v1", "Provider", "providers", "upbound-provider-aws-s3", "xpkg.upbound.io/upbound/provider-aws-s3:v0.47.0"),
+				pkg("pkg.crossplane.io/v1", "Provider", "providers", "example-provider-foo", "ghcr.io/example/provider-foo:v1.0.0"),
+				pkg("pkg.crossplane.io/v1", "Configuration", "configurations", "upbound-platform-ref-aws", "xpkg.upbound.io/upbound/platform-ref-aws:v0.9.0"),
+				pkg("pkg.crossplane.io/v1beta1", "Function", "functions", "crossplane-contrib-function-patch-and-transform", "xpkg.upbound.io/crossplane-contrib/function-patch-and-transform:v0.2.1"),
+			}},
+		},
+		"InvalidReference": {
+			reason: "An invalid package reference should be rejected.",
+			cmd:    &createCmd{WithFunction: []string{"Provider AWS"}},
+			want:   want{err: errInvalidRef},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.cmd.packages("xpkg.upbound.io")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npackages(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pkgs, got, cmp.AllowUnexported(bootstrapPackage{})); diff != "" {
+				t.Errorf("\n%s\npackages(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	gr := schema.GroupResource{Group: "pkg.crossplane.io", Resource: "providers"}
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"NoMatch": {
+			reason: "Package types that are not known yet should be retried.",
+			err:    &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "pkg.crossplane.io", Kind: "Provider"}},
+			want:   true,
+		},
+		"NotFound": {
+			reason: "Package types that are not served yet should be retried.",
+			err:    kerrors.NewNotFound(gr, "provider-aws"),
+			want:   true,
+		},
+		"ServiceUnavailable": {
+			reason: "An unavailable API server should be retried.",
+			err:    kerrors.NewServiceUnavailable("starting"),
+			want:   true,
+		},
+		"Forbidden": {
+			reason: "Missing permissions should not be retried.",
+			err:    kerrors.NewForbidden(gr, "provider-aws", errors.New("boom")),
+			want:   false,
+		},
+		"UpboundServerError": {
+			reason: "Upbound API server errors should be retried.",
+			err:    &uerrors.Error{Status: http.StatusBadGateway},
+			want:   true,
+		},
+		"UpboundUnauthorized": {
+			reason: "Upbound API authentication errors should not be retried.",
+			err:    &uerrors.Error{Status: http.StatusUnauthorized},
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isTransient(tc.err); got != tc.want {
+				t.Errorf("\n%s\nisTransient(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPollTransient(t *testing.T) {
+	errNotServed := kerrors.NewNotFound(schema.GroupResource{Group: "pkg.crossplane.io", Resource: "providers"}, "provider-aws")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := pollTransient(ctx, "timed out", func(_ context.Context) (bool, error) {
+		return false, errNotServed
+	})
+	if diff := cmp.Diff(errors.Wrap(errNotServed, "timed out"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("\npollTransient(...): transient errors should be retried until the context is done: -want err, +got err:\n%s", diff)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/pterm/pterm"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/upbound/up/cmd/up/uxp"
+	"github.com/upbound/up/internal/install/helm"
+	"github.com/upbound/up/internal/tracing"
+	"github.com/upbound/up/internal/upterm"
+	"github.com/upbound/up/internal/xpkg/dep/cache"
+	"github.com/upbound/up/internal/xpkg/dep/manager"
+	"github.com/upbound/up/internal/xpkg/dep/resolver/image"
+	"github.com/upbound/up/internal/xpkg/workspace"
+)
+
+const (
+	uxpNamespace = "upbound-system"
+
+	errKindNotFound    = "kind must be installed and on the PATH to create a local control plane"
+	errFmtKind         = "kind %s failed: %s"
+	errKubeconfig      = "unable to load kubeconfig of local control plane"
+	errParseWorkspace  = "unable to parse package in current directory"
+	errFmtResolveDep   = "unable to resolve version of %s from the package cache, run up xpkg dep to cache it"
+	errFmtInstallDep   = "unable to install %s"
+	errWatchEvents     = "unable to watch events"
+	errUnknownDepType  = "unknown dependency type"
+	errNoMetaInProject = "no crossplane.yaml found in current directory"
+)
+
+var (
+	providerGVR      = schema.GroupVersionResource{Group: "pkg.crossplane.io", Version: "v1", Resource: "providers"}
+	configurationGVR = schema.GroupVersionResource{Group: "pkg.crossplane.io", Version: "v1", Resource: "configurations"}
+)
+
+// devCmd runs a disposable local control plane.
+type devCmd struct {
+	Name       string `default:"up-dev" help:"Name of the kind cluster hosting the control plane."`
+	UXPVersion string `help:"UXP version to install. Defaults to the latest stable version."`
+	SkipDeps   bool   `help:"Do not install the dependencies of the package in the current directory."`
+	CacheDir   string `help:"Directory used for caching package images." default:"~/.up/cache/" env:"CACHE_DIR" type:"path"`
+	Keep       bool   `help:"Keep the control plane when the command exits."`
+}
+
+func (c *devCmd) Help() string {
+	return `
+The dev command creates a local control plane in a kind cluster, installs UXP
+into it, and installs the dependencies declared in the crossplane.yaml of the
+package in the current directory. It then prints the events of the control
+plane until it is interrupted, after which the kind cluster is deleted unless
+--keep is supplied. An existing kind cluster with the same name is reused, and
+is never deleted.
+
+The versions of the dependencies are resolved from the package cache, which
+is populated by "up xpkg dep".
+
+kind must be installed and on the PATH.`
+}
+
+// Run executes the dev command.
+func (c *devCmd) Run(p pterm.TextPrinter) error { //nolint:gocyclo
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if _, err := exec.LookPath("kind"); err != nil {
+		return errors.New(errKindNotFound)
+	}
+
+	var deps []v1beta1.Dependency
+	if !c.SkipDeps {
+		var err error
+		if deps, err = projectDeps(ctx, c.CacheDir); err != nil {
+			return err
+		}
+	}
+
+	// Only a cluster created by this run is deleted, so that an existing
+	// cluster that happens to have the same name is never lost.
+	created := false
+	create := func() error {
+		clusters, err := kind(ctx, "get", "clusters")
+		if err != nil {
+			return err
+		}
+		for _, n := range strings.Fields(clusters) {
+			if n == c.Name {
+				return nil
+			}
+		}
+		if _, err := kind(ctx, "create", "cluster", "--name", c.Name); err != nil {
+			return err
+		}
+		created = true
+		return nil
+	}
+	if err := upterm.WrapWithSuccessSpinner(upterm.StepCounter("Creating kind cluster", 1, 3), upterm.CheckmarkSuccessSpinner, create); err != nil {
+		return err
+	}
+	if !created {
+		p.Printfln("Using existing kind cluster %s, it will not be deleted", c.Name)
+	}
+	if created && !c.Keep {
+		defer func() {
+			// The context may already be cancelled, so the cluster is deleted
+			// with a fresh one.
+			if _, err := kind(context.Background(), "delete", "cluster", "--name", c.Name); err != nil {
+				pterm.Warning.Println(err.Error())
+				return
+			}
+			p.Printfln("Deleted kind cluster %s", c.Name)
+		}()
+	}
+
+	kc, err := kind(ctx, "get", "kubeconfig", "--name", c.Name)
+	if err != nil {
+		return err
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(kc))
+	if err != nil {
+		return errors.Wrap(err, errKubeconfig)
+	}
+	cfg.Wrap(tracing.Transport)
+
+	if err := upterm.WrapWithSuccessSpinner(upterm.StepCounter("Installing UXP", 2, 3), upterm.CheckmarkSuccessSpinner, func() error {
+		return c.installUXP(ctx, cfg)
+	}); err != nil {
+		return err
+	}
+
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if err := upterm.WrapWithSuccessSpinner(upterm.StepCounter("Installing dependencies", 3, 3), upterm.CheckmarkSuccessSpinner, func() error {
+		return installDeps(ctx, dClient, deps)
+	}); err != nil {
+		return err
+	}
+
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	p.Printfln("Control plane is running in kind cluster %s. Press Ctrl+C to exit.", c.Name)
+	return tailEvents(ctx, p, kClient)
+}
+
+func (c *devCmd) installUXP(ctx context.Context, cfg *rest.Config) error {
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = kClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: uxpNamespace}}, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	mgr, err := helm.NewManager(cfg,
+		uxp.ChartName,
+		uxp.RepoURL,
+		helm.WithNamespace(uxpNamespace),
+		helm.WithAlternateChart(uxp.AlternateChartName),
+		helm.Wait())
+	if err != nil {
+		return err
+	}
+	return mgr.Install(strings.TrimPrefix(c.UXPVersion, "v"), map[string]any{})
+}
+
+// projectDeps returns the dependencies of the package in the current
+// directory, with their versions resolved from the package cache.
+func projectDeps(ctx context.Context, cacheDir string) ([]v1beta1.Dependency, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	ws, err := workspace.New(wd, workspace.WithFS(afero.NewOsFs()))
+	if err != nil {
+		return nil, err
+	}
+	if err := ws.Parse(ctx); err != nil {
+		return nil, errors.Wrap(err, errParseWorkspace)
+	}
+	meta := ws.View().Meta()
+	if meta == nil {
+		return nil, errors.New(errNoMetaInProject)
+	}
+	deps, err := meta.DependsOn()
+	if err != nil {
+		return nil, err
+	}
+	ch, err := cache.NewLocal(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manager.New(manager.WithCache(ch), manager.WithOffline(true))
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range deps {
+		ud, _, err := m.Resolve(ctx, d)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtResolveDep, d.Package)
+		}
+		deps[i].Constraints = ud.Constraints
+	}
+	return deps, nil
+}
+
+// installDeps installs the supplied dependencies as packages.
+func installDeps(ctx context.Context, client dynamic.Interface, deps []v1beta1.Dependency) error {
+	for _, d := range deps {
+		gvr, kind := providerGVR, "Provider"
+		switch d.Type {
+		case v1beta1.ProviderPackageType:
+		case v1beta1.ConfigurationPackageType:
+			gvr, kind = configurationGVR, "Configuration"
+		default:
+			return errors.Wrapf(errors.New(errUnknownDepType), errFmtInstallDep, d.Package)
+		}
+		u := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": gvr.GroupVersion().String(),
+			"kind":       kind,
+			"metadata":   map[string]any{"name": packageName(d.Package)},
+			"spec":       map[string]any{"package": image.FullTag(d)},
+		}}
+		_, err := client.Resource(gvr).Create(ctx, u, metav1.CreateOptions{})
+		if err != nil && !kerrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, errFmtInstallDep, d.Package)
+		}
+	}
+	return nil
+}
+
+// packageName returns a name for the package object of the supplied package
+// source, e.g. provider-aws for xpkg.upbound.io/upbound/provider-aws.
+func packageName(source string) string {
+	return source[strings.LastIndex(source, "/")+1:]
+}
+
+// tailEvents prints the events of the control plane until the supplied
+// context is done.
+func tailEvents(ctx context.Context, p pterm.TextPrinter, client kubernetes.Interface) error {
+	w, err := client.CoreV1().Events(metav1.NamespaceAll).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, errWatchEvents)
+	}
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			e, ok := ev.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			io := e.InvolvedObject
+			p.Printfln("%s\t%s\t%s/%s\t%s", e.Type, e.Reason, strings.ToLower(io.Kind), io.Name, e.Message)
+		}
+	}
+}
+
+// kind runs the kind CLI with the supplied arguments and returns its output.
+func kind(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "kind", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, errFmtKind, strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/controlplane/state"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const absent = "-"
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *diffCmd) AfterApply(upCtx *upbound.Context) error {
+	for _, name := range []string{c.A, c.B} {
+		cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, name), c.Token, upCtx.WrapTransport)
+		if err != nil {
+			return err
+		}
+		dClient, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return err
+		}
+		c.clients = append(c.clients, dClient)
+	}
+	return nil
+}
+
+// diffCmd compares the Crossplane state of two control planes.
+type diffCmd struct {
+	clients []dynamic.Interface
+
+	A     string `arg:"" required:"" help:"Name of the first control plane." predictor:"ctps"`
+	B     string `arg:"" required:"" help:"Name of the second control plane." predictor:"ctps"`
+	Token string `required:"" help:"API token used to authenticate."`
+}
+
+func (c *diffCmd) Help() string {
+	return `
+The diff command reads the Crossplane state of two control planes and prints
+what differs between them: installed packages and their versions, the
+referenceable versions of XRDs, the hash of the latest revision of each
+Composition, and the number of claims per claim type. Nothing is printed for
+objects that are the same in both control planes.`
+}
+
+// Run executes the diff command.
+func (c *diffCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	ctx := context.Background()
+	states := make([]*state.State, len(c.clients))
+	for i, client := range c.clients {
+		s, err := state.Collect(ctx, client)
+		if err != nil {
+			return err
+		}
+		states[i] = s
+	}
+	changes := state.Diff(states[0], states[1])
+	if len(changes) == 0 {
+		p.Printfln("No differences found between %s and %s", c.A, c.B)
+		return nil
+	}
+	return printer.Print(changes, []string{"CATEGORY", "NAME", strings.ToUpper(c.A), strings.ToUpper(c.B)}, extractDiffFields)
+}
+
+func extractDiffFields(obj any) []string {
+	c := obj.(state.Change)
+	a, b := c.A, c.B
+	if a == "" {
+		a = absent
+	}
+	if b == "" {
+		b = absent
+	}
+	return []string{c.Category, c.Name, a, b}
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/resources"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errFmtInvalidFor = "invalid resource %q, must be of the form <type>/<name>"
+	errListEvents    = "unable to list events"
+)
+
+var eventFieldNames = []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *eventsCmd) AfterApply(upCtx *upbound.Context) error {
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.Name), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	mapper, err := kube.NewDiscoveryRESTMapper(cfg)
+	if err != nil {
+		return err
+	}
+	c.kClient = kClient
+	c.dClient = dClient
+	c.mapper = mapper
+	return nil
+}
+
+// eventsCmd displays the Kubernetes events inside of a control plane.
+type eventsCmd struct {
+	kClient kubernetes.Interface
+	dClient dynamic.Interface
+	mapper  meta.RESTMapper
+
+	Name string `arg:"" required:"" help:"Name of control plane." predictor:"ctps"`
+
+	Token     string `required:"" help:"API token used to authenticate."`
+	For       string `help:"Only show events for the given resource and its descendants, e.g. postgresqlinstances/my-db."`
+	Namespace string `short:"n" default:"default" help:"Namespace of the resource given with --for, if it is namespaced."`
+}
+
+// objectKey identifies an object that events may be recorded for.
+type objectKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// Run executes the events command.
+func (c *eventsCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	ctx := context.Background()
+
+	var filter map[objectKey]bool
+	if c.For != "" {
+		var err error
+		if filter, err = c.resourceTree(ctx); err != nil {
+			return err
+		}
+	}
+
+	l, err := c.kClient.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, errListEvents)
+	}
+	events := make([]corev1.Event, 0, len(l.Items))
+	for _, e := range l.Items {
+		io := e.InvolvedObject
+		if filter != nil && !filter[objectKey{kind: io.Kind, namespace: io.Namespace, name: io.Name}] {
+			continue
+		}
+		events = append(events, e)
+	}
+	if len(events) == 0 {
+		p.Printfln("No events found in control plane %s", c.Name)
+		return nil
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return printer.Print(events, eventFieldNames, extractEventFields)
+}
+
+// resourceTree returns the keys of the resource passed with --for and all of
+// the resources it transitively composes.
+func (c *eventsCmd) resourceTree(ctx context.Context) (map[objectKey]bool, error) {
+	res, name, ok := strings.Cut(c.For, "/")
+	if !ok || res == "" || name == "" {
+		return nil, errors.Errorf(errFmtInvalidFor, c.For)
+	}
+	m, err := kube.MappingFor(c.mapper, res)
+	if err != nil {
+		return nil, err
+	}
+	ns := ""
+	if m.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns = c.Namespace
+	}
+	root, err := c.dClient.Resource(m.Resource).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[objectKey]bool{}
+	queue := []*unstructured.Unstructured{root}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		k := objectKey{kind: u.GetKind(), namespace: u.GetNamespace(), name: u.GetName()}
+		if keys[k] {
+			continue
+		}
+		keys[k] = true
+		for _, ref := range resources.GetChildRefs(u) {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil {
+				continue
+			}
+			rm, err := c.mapper.RESTMapping(gv.WithKind(ref.Kind).GroupKind(), gv.Version)
+			if err != nil {
+				// The child may belong to an API that is no longer served, we
+				// still want to include its events.
+				keys[objectKey{kind: ref.Kind, namespace: ref.Namespace, name: ref.Name}] = true
+				continue
+			}
+			child, err := c.dClient.Resource(rm.Resource).Namespace(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				keys[objectKey{kind: ref.Kind, namespace: ref.Namespace, name: ref.Name}] = true
+				continue
+			}
+			queue = append(queue, child)
+		}
+	}
+	return keys, nil
+}
+
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+func extractEventFields(obj any) []string {
+	e := obj.(corev1.Event)
+	return []string{
+		duration.HumanDuration(time.Since(eventTime(e))),
+		e.Type,
+		e.Reason,
+		fmt.Sprintf("%s/%s", strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name),
+		strings.TrimSpace(e.Message),
+	}
+}
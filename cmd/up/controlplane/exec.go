@@ -0,0 +1,284 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/tracing"
+)
+
+const (
+	errListSpaceControlPlanes = "unable to list control planes of the Space"
+	errNoMatchingCtps         = "no control planes match the selector"
+	errCtpNotAvailable        = "control plane is not available yet"
+	errFmtExecFailed          = "command failed in %d of %d control planes"
+	errInvalidConcurrency     = "concurrency must be at least 1"
+	errCommandOrQuery         = "either a command or --query must be supplied"
+)
+
+var spaceControlPlaneGVR = schema.GroupVersionResource{Group: "spaces.upbound.io", Version: "v1beta1", Resource: "controlplanes"}
+
+// An execQuery is a built-in query of the exec command. It lists the objects
+// of a resource and prints their name, readiness and the field at path, if
+// any.
+type execQuery struct {
+	gvr    schema.GroupVersionResource
+	column string
+	path   string
+}
+
+var execQueries = map[string]execQuery{
+	"providers":      {gvr: schema.GroupVersionResource{Group: "pkg.crossplane.io", Version: "v1", Resource: "providers"}, column: "PACKAGE", path: "spec.package"},
+	"configurations": {gvr: schema.GroupVersionResource{Group: "pkg.crossplane.io", Version: "v1", Resource: "configurations"}, column: "PACKAGE", path: "spec.package"},
+	"functions":      {gvr: schema.GroupVersionResource{Group: "pkg.crossplane.io", Version: "v1beta1", Resource: "functions"}, column: "PACKAGE", path: "spec.package"},
+	"compositions":   {gvr: schema.GroupVersionResource{Group: "apiextensions.crossplane.io", Version: "v1", Resource: "compositions"}, column: "XR-KIND", path: "spec.compositeTypeRef.kind"},
+	"xrds":           {gvr: schema.GroupVersionResource{Group: "apiextensions.crossplane.io", Version: "v1", Resource: "compositeresourcedefinitions"}},
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *execCmd) AfterApply() error {
+	if c.Concurrency < 1 {
+		return errors.New(errInvalidConcurrency)
+	}
+	if (c.Query == "") == (len(c.Command) == 0) {
+		return errors.New(errCommandOrQuery)
+	}
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
+	return nil
+}
+
+// execCmd runs a command against many control planes of a Space.
+type execCmd struct {
+	dClient dynamic.Interface
+
+	Selector    string `short:"l" help:"Label selector of the control planes to run the command against, e.g. env=dev."`
+	Group       string `short:"g" help:"Control plane group to select control planes from. Defaults to all groups."`
+	Concurrency int    `default:"10" help:"Maximum number of control planes to run the command against at the same time."`
+	Kubeconfig  string `type:"existingfile" help:"Override default kubeconfig path of the Space."`
+	Query       string `enum:",providers,configurations,functions,compositions,xrds" default:"" help:"Built-in read-only query to run instead of a command. Can be: providers, configurations, functions, compositions, xrds"`
+
+	Command []string `arg:"" optional:"" passthrough:"" help:"Command to run, e.g. kubectl get providers."`
+}
+
+func (c *execCmd) Help() string {
+	return `
+The exec command runs a command, such as kubectl, against every control plane of
+a Space that matches the label selector. The command runs concurrently for each
+control plane with KUBECONFIG pointing to the kubeconfig of that control plane.
+The output of each control plane is printed as soon as its command completes,
+with every line prefixed with the group and name of the control plane.
+
+The command is run as is, with the environment of up and the credentials of
+the connection secret of each control plane, which usually grant full access
+to it. Nothing prevents the command from changing state. To inspect a fleet of
+control planes safely, use a built-in query with --query instead of a command.
+Queries run within up, list the providers, configurations, functions,
+compositions or XRDs of each control plane with their readiness, and never
+change state. Commands are refused with --read-only, since exec cannot tell
+whether they change state, but queries are allowed.
+
+Examples:
+
+    up controlplane exec --selector env=dev --query providers
+
+    up controlplane exec --selector env=dev -- kubectl get providers
+`
+}
+
+// Mutating returns true if the exec command runs a command, which may change
+// state. Built-in queries only read state.
+func (c *execCmd) Mutating() bool {
+	return c.Query == ""
+}
+
+type execResult struct {
+	name string
+	out  []byte
+	err  error
+}
+
+// Run executes the exec command.
+func (c *execCmd) Run(kongCtx *kong.Context) error {
+	ctx := context.Background()
+	l, err := c.dClient.Resource(spaceControlPlaneGVR).Namespace(c.Group).List(ctx, metav1.ListOptions{LabelSelector: c.Selector})
+	if err != nil {
+		return errors.Wrap(err, errListSpaceControlPlanes)
+	}
+	if len(l.Items) == 0 {
+		return errors.New(errNoMatchingCtps)
+	}
+	sort.Slice(l.Items, func(i, j int) bool {
+		return path.Join(l.Items[i].GetNamespace(), l.Items[i].GetName()) < path.Join(l.Items[j].GetNamespace(), l.Items[j].GetName())
+	})
+
+	mu := sync.Mutex{}
+	failed := 0
+	g := errgroup.Group{}
+	g.SetLimit(c.Concurrency)
+	for i := range l.Items {
+		ctp := &l.Items[i]
+		g.Go(func() error {
+			res := c.exec(ctx, ctp)
+			mu.Lock()
+			defer mu.Unlock()
+			if res.err != nil {
+				failed++
+			}
+			return writeExecResult(kongCtx.Stdout, res)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Errorf(errFmtExecFailed, failed, len(l.Items))
+	}
+	return nil
+}
+
+func (c *execCmd) exec(ctx context.Context, ctp *unstructured.Unstructured) execResult {
+	res := execResult{name: path.Join(ctp.GetNamespace(), ctp.GetName())}
+	ns, name := kube.ConnectionSecretRef(ctp)
+	kubeconfig, err := kube.ConnectionSecretKubeconfig(ctx, c.dClient, ns, name)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	if kubeconfig == nil {
+		res.err = errors.New(errCtpNotAvailable)
+		return res
+	}
+
+	if c.Query != "" {
+		res.out, res.err = runQuery(ctx, kubeconfig, execQueries[c.Query])
+		return res
+	}
+
+	f, err := os.CreateTemp("", "up-exec-kubeconfig-")
+	if err != nil {
+		res.err = err
+		return res
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+	if _, err := f.Write(kubeconfig); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		res.err = err
+		return res
+	}
+	if err := f.Close(); err != nil {
+		res.err = err
+		return res
+	}
+
+	cmd := exec.CommandContext(ctx, c.Command[0], c.Command[1:]...) //nolint:gosec
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+f.Name())
+	res.out, res.err = cmd.CombinedOutput()
+	return res
+}
+
+// runQuery runs the supplied query against the control plane with the
+// supplied kubeconfig and returns its output.
+func runQuery(ctx context.Context, kubeconfig []byte, q execQuery) ([]byte, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Wrap(tracing.Transport)
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return queryOutput(ctx, client, q)
+}
+
+// queryOutput lists the objects of the supplied query and formats them as a
+// table.
+func queryOutput(ctx context.Context, client dynamic.Interface, q execQuery) ([]byte, error) {
+	l, err := client.Resource(q.gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(l.Items) == 0 {
+		return []byte(fmt.Sprintf("No %s found\n", q.gvr.Resource)), nil
+	}
+	b := &bytes.Buffer{}
+	w := tabwriter.NewWriter(b, 0, 4, 2, ' ', 0)
+	header := []string{"NAME", "READY"}
+	if q.column != "" {
+		header = append(header, q.column)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for i := range l.Items {
+		u := &l.Items[i]
+		row := []string{u.GetName(), strconv.FormatBool(kube.IsReady(u))}
+		if q.path != "" {
+			v, _ := fieldpath.Pave(u.Object).GetString(q.path)
+			row = append(row, v)
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// writeExecResult writes the output of a command, prefixing each line with the
+// name of the control plane it ran against.
+func writeExecResult(w io.Writer, res execResult) error {
+	b := &strings.Builder{}
+	for _, line := range bytes.Split(bytes.TrimRight(res.out, "\n"), []byte("\n")) {
+		if len(line) == 0 && len(res.out) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "[%s] %s\n", res.name, line)
+	}
+	if res.err != nil {
+		fmt.Fprintf(b, "[%s] error: %s\n", res.name, res.err)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestQueryOutput(t *testing.T) {
+	provider := func(name, pkg, healthy string) runtime.Object {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "pkg.crossplane.io/v1",
+			"kind":       "Provider",
+			"metadata":   map[string]any{"name": name},
+			"spec":       map[string]any{"package": pkg},
+			"status": map[string]any{"conditions": []any{
+				map[string]any{"type": "Installed", "status": "True"},
+				map[string]any{"type": "Healthy", "status": healthy},
+			}},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason  string
+		query   string
+		objects []runtime.Object
+		want    string
+	}{
+		"Providers": {
+			reason: "The providers query should print the name, readiness and package of each provider.",
+			query:  "providers",
+			objects: []runtime.Object{
+				provider("provider-aws-s3", "xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0", "True"),
+				provider("provider-helm", "xpkg.upbound.io/crossplane-contrib/provider-helm:v0.15.0", "False"),
+			},
+			want: "NAME             READY  PACKAGE\n" +
+				"provider-aws-s3  true   xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0\n" +
+				"provider-helm    false  xpkg.upbound.io/crossplane-contrib/provider-helm:v0.15.0\n",
+		},
+		"NoObjects": {
+			reason: "A query should say so if there are no objects.",
+			query:  "xrds",
+			want:   "No compositeresourcedefinitions found\n",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lists := map[schema.GroupVersionResource]string{}
+			for _, q := range execQueries {
+				lists[q.gvr] = "List"
+			}
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), lists, tc.objects...)
+			got, err := queryOutput(context.Background(), client, execQueries[tc.query])
+			if err != nil {
+				t.Fatalf("queryOutput(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\nqueryOutput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
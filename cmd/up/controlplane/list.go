@@ -35,7 +35,13 @@ const (
 	notAvailable = "n/a"
 )
 
-var fieldNames = []string{"NAME", "ID", "STATUS", "DEPLOYED CONFIGURATION", "CONFIGURATION STATUS"}
+const (
+	healthHealthy     = "Healthy"
+	healthProgressing = "Progressing"
+	healthUnhealthy   = "Unhealthy"
+)
+
+var fieldNames = []string{"NAME", "ID", "STATUS", "DEPLOYED CONFIGURATION", "CONFIGURATION STATUS", "HEALTH"}
 
 // AfterApply sets default values in command after assignment and validation.
 func (c *listCmd) AfterApply(kongCtx *kong.Context, upCtx *upbound.Context) error {
@@ -44,7 +50,9 @@ func (c *listCmd) AfterApply(kongCtx *kong.Context, upCtx *upbound.Context) erro
 }
 
 // listCmd list control planes in an account on Upbound.
-type listCmd struct{}
+type listCmd struct {
+	UnhealthyOnly bool `help:"Only list control planes that are not healthy."`
+}
 
 // Run executes the list command.
 func (c *listCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, cc *cp.Client, upCtx *upbound.Context) error {
@@ -59,7 +67,20 @@ func (c *listCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, cc *cp.
 		p.Printfln("No control planes found in %s", upCtx.Account)
 		return nil
 	}
-	return printer.Print(cpList.ControlPlanes, fieldNames, extractFields)
+	ctps := cpList.ControlPlanes
+	if c.UnhealthyOnly {
+		ctps = make([]cp.ControlPlaneResponse, 0, len(cpList.ControlPlanes))
+		for _, ctp := range cpList.ControlPlanes {
+			if health(ctp) != healthHealthy {
+				ctps = append(ctps, ctp)
+			}
+		}
+		if len(ctps) == 0 {
+			p.Printfln("All control planes in %s are healthy", upCtx.Account)
+			return nil
+		}
+	}
+	return printer.Print(ctps, fieldNames, extractFields)
 }
 
 func extractFields(obj any) []string {
@@ -74,5 +95,39 @@ func extractFields(obj any) []string {
 	} else {
 		cfgName, cfgStatus = notAvailable, notAvailable
 	}
-	return []string{c.ControlPlane.Name, c.ControlPlane.ID.String(), string(c.Status), cfgName, cfgStatus}
+	return []string{c.ControlPlane.Name, c.ControlPlane.ID.String(), string(c.Status), cfgName, cfgStatus, colorHealth(health(c))}
+}
+
+// health rolls the status of a control plane and its configuration up into a
+// single value.
+func health(c cp.ControlPlaneResponse) string {
+	switch c.Status {
+	case cp.StatusProvisioning, cp.StatusUpdating, cp.StatusDeleting:
+		return healthProgressing
+	case cp.StatusReady:
+	default:
+		return healthUnhealthy
+	}
+	if c.ControlPlane.Configuration.Name == nil || c.ControlPlane.Configuration == EmptyControlPlaneConfiguration() {
+		return healthHealthy
+	}
+	switch c.ControlPlane.Configuration.Status {
+	case cp.ConfigurationReady:
+		return healthHealthy
+	case cp.ConfigurationInstallationQueued, cp.ConfigurationUpgradeQueued, cp.ConfigurationInstalling, cp.ConfigurationUpgrading:
+		return healthProgressing
+	default:
+		return healthUnhealthy
+	}
+}
+
+func colorHealth(h string) string {
+	switch h {
+	case healthHealthy:
+		return upterm.SuccessStyle.Sprint(h)
+	case healthProgressing:
+		return upterm.WarningStyle.Sprint(h)
+	default:
+		return upterm.ErrorStyle.Sprint(h)
+	}
 }
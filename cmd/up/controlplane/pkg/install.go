@@ -139,25 +139,26 @@ func (c *installCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
 		return nil
 	}
 
-	s, _ := upterm.CheckmarkSuccessSpinner.Start(fmt.Sprintf("%s installed. Waiting to become healthy...", c.Name))
-
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	t := int64(c.Wait.Seconds())
-	errC, err := kube.DynamicWatch(ctx, c.r, &t, func(u *unstructured.Unstructured) (bool, error) {
-		pkg := resources.Package{Unstructured: *u}
-		if pkg.GetInstalled() && pkg.GetHealthy() {
-			return true, nil
+	waitHealthy := func() error {
+		errC, err := kube.DynamicWatch(ctx, c.r, &t, func(u *unstructured.Unstructured) (bool, error) {
+			pkg := resources.Package{Unstructured: *u}
+			if pkg.GetInstalled() && pkg.GetHealthy() {
+				return true, nil
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
 		}
-		return false, nil
-	})
-	if err != nil {
-		return err
-	}
-	if err := <-errC; err != nil {
-		return err
+		return <-errC
 	}
 
-	s.Success(fmt.Sprintf("%s installed and healthy", c.Name))
-	return nil
+	return upterm.WrapWithSuccessSpinner(
+		fmt.Sprintf("%s installed. Waiting to become healthy", c.Name),
+		upterm.CheckmarkSuccessSpinner,
+		waitHealthy,
+	)
 }
@@ -0,0 +1,124 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	crossplaneComponent = "crossplane"
+	providerLabel       = "pkg.crossplane.io/provider"
+
+	errFmtListDeployments = "unable to list deployments of %s"
+	errFmtNoDeployments   = "no deployments found for component %s"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *restartCmd) AfterApply(upCtx *upbound.Context) error {
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.Name), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	c.kClient = kClient
+	return nil
+}
+
+// restartCmd performs a rolling restart of Crossplane or provider deployments
+// inside a control plane.
+type restartCmd struct {
+	kClient kubernetes.Interface
+
+	Name string `arg:"" required:"" help:"Name of control plane." predictor:"ctps"`
+
+	Token     string        `required:"" help:"API token used to authenticate."`
+	Component []string      `default:"crossplane" help:"Component to restart, either crossplane or the name of a provider. May be repeated."`
+	Namespace string        `short:"n" default:"crossplane-system" help:"Namespace Crossplane and its providers run in."`
+	Timeout   time.Duration `default:"5m" help:"How long to wait for each deployment to become ready."`
+}
+
+func (c *restartCmd) Help() string {
+	return `
+The restart command performs a rolling restart of the deployments of the given
+components inside a control plane, one after another, and waits for each to
+become ready. This is useful after changing a DeploymentRuntimeConfig.
+
+Providers are identified by the name of their Provider package, for example
+--component provider-aws-s3.`
+}
+
+// Run executes the restart command.
+func (c *restartCmd) Run(p pterm.TextPrinter) error {
+	ctx := context.Background()
+	deployments := []string{}
+	for _, comp := range c.Component {
+		d, err := c.deployments(ctx, comp)
+		if err != nil {
+			return err
+		}
+		deployments = append(deployments, d...)
+	}
+	for i, d := range deployments {
+		restart := func() error {
+			return kube.RolloutRestart(ctx, c.kClient, c.Namespace, d, c.Timeout)
+		}
+		if err := upterm.WrapWithSuccessSpinner(
+			upterm.StepCounter(fmt.Sprintf("Restarting %s", d), i+1, len(deployments)),
+			upterm.CheckmarkSuccessSpinner,
+			restart,
+		); err != nil {
+			return err
+		}
+	}
+	p.Printfln("%s restarted", c.Name)
+	return nil
+}
+
+// deployments returns the names of the deployments of the supplied component.
+func (c *restartCmd) deployments(ctx context.Context, component string) ([]string, error) {
+	if component == crossplaneComponent {
+		return []string{crossplaneComponent}, nil
+	}
+	l, err := c.kClient.AppsV1().Deployments(c.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: providerLabel + "=" + component,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtListDeployments, component)
+	}
+	if len(l.Items) == 0 {
+		return nil, errors.Errorf(errFmtNoDeployments, component)
+	}
+	names := make([]string, len(l.Items))
+	for i, d := range l.Items {
+		names[i] = d.GetName()
+	}
+	return names, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	// minTokenDuration is the shortest token lifetime accepted by the
+	// Kubernetes TokenRequest API.
+	minTokenDuration = 10 * time.Minute
+
+	errFmtTokenDuration = "--duration must be at least %s"
+	errCreateSA         = "unable to create service account"
+	errRequestToken     = "unable to request token"
+	errWriteTokenFile   = "unable to write token file"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *tokenCmd) AfterApply(upCtx *upbound.Context) error {
+	if c.Duration < minTokenDuration {
+		return errors.Errorf(errFmtTokenDuration, minTokenDuration)
+	}
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.Name), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	c.kClient = kClient
+	return nil
+}
+
+// tokenCmd mints a short-lived token for a service account in a control plane.
+type tokenCmd struct {
+	kClient kubernetes.Interface
+
+	Name string `arg:"" required:"" help:"Name of control plane." predictor:"ctps"`
+
+	Token          string        `required:"" help:"API token used to authenticate."`
+	Duration       time.Duration `default:"1h" help:"Lifetime of the minted token."`
+	Audience       []string      `help:"Audiences of the minted token. Defaults to the audience of the control plane API server."`
+	ServiceAccount string        `default:"up-token" help:"Service account the token is minted for. It is created if it does not exist."`
+	Namespace      string        `short:"n" default:"default" help:"Namespace of the service account."`
+	Output         string        `type:"path" short:"o" help:"Path to write the token to. The token is printed if not supplied."`
+}
+
+func (c *tokenCmd) Help() string {
+	return `
+The token command mints a short-lived token scoped to a single control plane,
+for use by external systems such as CI pipelines applying claims, instead of
+sharing the API token of a user.
+
+The token is issued for a service account inside the control plane, and grants
+only the permissions bound to that service account. Bind the required roles to
+it, for example with "kubectl create rolebinding", before using the token.`
+}
+
+// Run executes the token command.
+func (c *tokenCmd) Run(p pterm.TextPrinter) error {
+	ctx := context.Background()
+	sas := c.kClient.CoreV1().ServiceAccounts(c.Namespace)
+	_, err := sas.Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: c.ServiceAccount, Namespace: c.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, errCreateSA)
+	}
+
+	exp := int64(c.Duration.Seconds())
+	tr, err := sas.CreateToken(ctx, c.ServiceAccount, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         c.Audience,
+			ExpirationSeconds: &exp,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, errRequestToken)
+	}
+
+	if c.Output == "" {
+		// The token is the output of the command, so it is written to
+		// stdout even when other output is suppressed with --quiet.
+		_, err := fmt.Fprintln(os.Stdout, tr.Status.Token)
+		return err
+	}
+	if err := os.WriteFile(filepath.Clean(c.Output), []byte(tr.Status.Token), 0600); err != nil {
+		return errors.Wrap(err, errWriteTokenFile)
+	}
+	p.Printfln("Token for %s/%s written to %s, expires at %s", c.Namespace, c.ServiceAccount, c.Output, tr.Status.ExpirationTimestamp.Format(time.RFC3339))
+	return nil
+}
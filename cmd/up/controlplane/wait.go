@@ -0,0 +1,117 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	cp "github.com/upbound/up-sdk-go/service/controlplanes"
+
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	waitForCondition            = "condition"
+	waitForConfiguration        = "configuration"
+	waitForConfigurationVersion = "configuration-version"
+
+	errFmtWaitFor = "invalid --for %q: must be one of condition=<status>, configuration=<status> or configuration-version=<version>"
+	errFmtTimeout = "control plane %s did not meet %s within %s"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *waitCmd) AfterApply() error {
+	k, v, ok := strings.Cut(c.For, "=")
+	if !ok || v == "" {
+		return errors.Errorf(errFmtWaitFor, c.For)
+	}
+	switch k {
+	case waitForCondition, waitForConfiguration, waitForConfigurationVersion:
+	default:
+		return errors.Errorf(errFmtWaitFor, c.For)
+	}
+	c.key, c.value = k, v
+	return nil
+}
+
+// waitCmd waits for a control plane to meet a condition.
+type waitCmd struct {
+	key   string
+	value string
+
+	Name    string        `arg:"" required:"" help:"Name of control plane." predictor:"ctps"`
+	For     string        `default:"condition=Ready" help:"Condition to wait for. One of condition=<status>, configuration=<status> or configuration-version=<version>."`
+	Timeout time.Duration `default:"10m" help:"How long to wait before giving up."`
+}
+
+func (c *waitCmd) Help() string {
+	return `
+The wait command polls a control plane until it meets the condition supplied
+with --for, and exits with an error if it does not do so within --timeout.
+
+  condition=<status>              Status of the control plane, e.g. Ready.
+  configuration=<status>          Status of the control plane's configuration,
+                                  e.g. Ready or Upgrading.
+  configuration-version=<version> Version of the configuration currently
+                                  installed in the control plane.
+
+Statuses are compared case-insensitively.`
+}
+
+// Run executes the wait command.
+func (c *waitCmd) Run(p pterm.TextPrinter, cc *cp.Client, upCtx *upbound.Context) error {
+	ctx := context.Background()
+	poll := func() error {
+		err := wait.PollUntilContextTimeout(ctx, readyPollInterval, c.Timeout, true, func(ctx context.Context) (bool, error) {
+			ctp, err := cc.Get(ctx, upCtx.Account, c.Name)
+			if err != nil {
+				return false, err
+			}
+			return c.met(ctp), nil
+		})
+		if wait.Interrupted(err) {
+			return errors.Errorf(errFmtTimeout, c.Name, c.For, c.Timeout)
+		}
+		return err
+	}
+	if err := upterm.WrapWithSuccessSpinner(fmt.Sprintf("Waiting for %s to meet %s", c.Name, c.For), upterm.CheckmarkSuccessSpinner, poll); err != nil {
+		return err
+	}
+	p.Printfln("%s met %s", c.Name, c.For)
+	return nil
+}
+
+// met returns true if the supplied control plane meets the condition of the
+// command.
+func (c *waitCmd) met(ctp *cp.ControlPlaneResponse) bool {
+	cfg := ctp.ControlPlane.Configuration
+	switch c.key {
+	case waitForCondition:
+		return strings.EqualFold(string(ctp.Status), c.value)
+	case waitForConfiguration:
+		return strings.EqualFold(string(cfg.Status), c.value)
+	case waitForConfigurationVersion:
+		return cfg.CurrentVersion != nil && strings.TrimPrefix(*cfg.CurrentVersion, "v") == strings.TrimPrefix(c.value, "v")
+	}
+	return false
+}
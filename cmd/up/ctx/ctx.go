@@ -0,0 +1,54 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctx
+
+import (
+	"github.com/alecthomas/kong"
+
+	"github.com/upbound/up/internal/upbound"
+)
+
+// AfterApply constructs and binds Upbound-specific context to any subcommands
+// that have Run() methods that receive it.
+func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	kongCtx.Bind(upCtx)
+	return nil
+}
+
+// Cmd contains commands for managing context bookmarks.
+type Cmd struct {
+	Save   saveCmd   `cmd:"" mutating:"" help:"Save a bookmark of a profile, Space, group and control plane."`
+	Use    useCmd    `cmd:"" mutating:"" help:"Switch to the context of a bookmark."`
+	List   listCmd   `cmd:"" help:"List bookmarks."`
+	Delete deleteCmd `cmd:"" help:"Delete a bookmark."`
+
+	Flags upbound.Flags `embed:""`
+}
+
+func (c *Cmd) Help() string {
+	return `
+Bookmarks are named combinations of an Upbound profile and account, the
+kubeconfig context of a Space, a control plane group and a control plane. They
+are stored in the up configuration file and allow switching between many
+control planes with a single command.
+
+Examples:
+  up ctx save prod-team-a --space=prod --group=team-a --controlplane=ctp1
+  up ctx use prod-team-a`
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctx
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/upbound"
+)
+
+// deleteCmd deletes a bookmark.
+type deleteCmd struct {
+	Name string `arg:"" required:"" help:"Name of the bookmark."`
+}
+
+// Run executes the delete command.
+func (c *deleteCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
+	if err := upCtx.Cfg.RemoveBookmark(c.Name); err != nil {
+		return err
+	}
+	if err := upCtx.CfgSrc.UpdateConfig(upCtx.Cfg); err != nil {
+		return errors.Wrap(err, errUpdateConfig)
+	}
+	p.Printfln("Bookmark %s deleted", c.Name)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctx
+
+import (
+	"sort"
+
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+// listCmd lists bookmarks.
+type listCmd struct{}
+
+type bookmark struct {
+	Name string `json:"name"`
+	config.Bookmark
+}
+
+var fieldNames = []string{"NAME", "PROFILE", "ACCOUNT", "SPACE", "GROUP", "CONTROL PLANE"}
+
+// Run executes the list command.
+func (c *listCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, upCtx *upbound.Context) error {
+	if len(upCtx.Cfg.Upbound.Bookmarks) == 0 {
+		p.Println("No bookmarks found")
+		return nil
+	}
+	bookmarks := make([]bookmark, 0, len(upCtx.Cfg.Upbound.Bookmarks))
+	for n, b := range upCtx.Cfg.Upbound.Bookmarks {
+		bookmarks = append(bookmarks, bookmark{Name: n, Bookmark: b})
+	}
+	sort.Slice(bookmarks, func(i, j int) bool {
+		return bookmarks[i].Name < bookmarks[j].Name
+	})
+	return printer.Print(bookmarks, fieldNames, extractFields)
+}
+
+func extractFields(obj any) []string {
+	b := obj.(bookmark)
+	return []string{b.Name, b.Profile, b.Account, b.Space, b.Group, b.ControlPlane}
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctx
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	errUpdateConfig = "unable to update config"
+)
+
+// saveCmd saves a bookmark.
+type saveCmd struct {
+	Name string `arg:"" required:"" help:"Name of the bookmark."`
+
+	Space        string `help:"Kubeconfig context of the Space."`
+	Group        string `short:"g" help:"Control plane group in the Space."`
+	ControlPlane string `name:"controlplane" help:"Name of the control plane."`
+}
+
+// Run executes the save command.
+func (c *saveCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
+	b := config.Bookmark{
+		Profile:      upCtx.ProfileName,
+		Account:      upCtx.Account,
+		Space:        c.Space,
+		Group:        c.Group,
+		ControlPlane: c.ControlPlane,
+	}
+	if err := upCtx.Cfg.AddOrUpdateBookmark(c.Name, b); err != nil {
+		return err
+	}
+	if err := upCtx.CfgSrc.UpdateConfig(upCtx.Cfg); err != nil {
+		return errors.Wrap(err, errUpdateConfig)
+	}
+	p.Printfln("Bookmark %s saved", c.Name)
+	return nil
+}
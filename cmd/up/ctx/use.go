@@ -0,0 +1,87 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctx
+
+import (
+	"path"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	errFmtUseSpace        = "unable to switch to Space %s"
+	errFmtUseControlPlane = "unable to switch to control plane %s, run \"up controlplane kubeconfig get\" to add it to the kubeconfig"
+)
+
+// useCmd switches to the context of a bookmark.
+type useCmd struct {
+	Name string `arg:"" required:"" help:"Name of the bookmark."`
+
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+func (c *useCmd) Help() string {
+	return `
+The use command selects the profile of the bookmark as the default profile and
+sets its account. If the bookmark has a Space, the Space becomes the current
+kubeconfig context, with the group of the bookmark as its namespace. If the
+bookmark has a control plane, the kubeconfig context of the control plane, as
+created by "up controlplane kubeconfig get", becomes the current context.`
+}
+
+// Run executes the use command.
+func (c *useCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
+	b, err := upCtx.Cfg.GetBookmark(c.Name)
+	if err != nil {
+		return err
+	}
+	prof, err := upCtx.Cfg.GetUpboundProfile(b.Profile)
+	if err != nil {
+		return err
+	}
+	if b.Account != "" && b.Account != prof.Account {
+		prof.Account = b.Account
+		if err := upCtx.Cfg.AddOrUpdateUpboundProfile(b.Profile, prof); err != nil {
+			return err
+		}
+	}
+	if err := upCtx.Cfg.SetDefaultUpboundProfile(b.Profile); err != nil {
+		return err
+	}
+	if err := upCtx.CfgSrc.UpdateConfig(upCtx.Cfg); err != nil {
+		return errors.Wrap(err, errUpdateConfig)
+	}
+
+	if b.Space != "" {
+		if err := kube.UseContext(c.Kubeconfig, b.Space, b.Group); err != nil {
+			return errors.Wrapf(err, errFmtUseSpace, b.Space)
+		}
+	}
+	if b.ControlPlane != "" {
+		account := b.Account
+		if account == "" {
+			account = prof.Account
+		}
+		if err := kube.UseContext(c.Kubeconfig, kube.ControlPlaneContext(path.Join(account, b.ControlPlane)), ""); err != nil {
+			return errors.Wrapf(err, errFmtUseControlPlane, b.ControlPlane)
+		}
+	}
+	p.Printfln("Switched to bookmark %s", c.Name)
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/fleet"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const errReadFleet = "unable to read fleet file"
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *applyCmd) AfterApply() error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
+	return nil
+}
+
+// applyCmd reconciles the control planes of a Space with a fleet file.
+type applyCmd struct {
+	dClient dynamic.Interface
+
+	File   string `short:"f" required:"" type:"existingfile" help:"Fleet file declaring the desired control planes."`
+	DryRun bool   `help:"Only print the plan, without applying it."`
+	Yes    bool   `help:"Apply the plan without asking for confirmation."`
+
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+func (c *applyCmd) Help() string {
+	return `
+The apply command reconciles the control planes of a Space with a fleet file,
+which declares the desired control planes, their group, class and Crossplane
+version, and the packages to install in them:
+
+    name: platform
+    controlPlanes:
+    - name: ctp1
+      group: default
+      class: small
+      crossplaneVersion: 1.14.1
+      packages:
+      - kind: Provider
+        package: xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0
+
+The command first prints a plan of the control planes to create, update and
+delete, and asks for confirmation before applying it. Control planes created by
+a fleet are labelled with its name. Only those are deleted when they are no
+longer declared.
+
+Packages are installed once a control plane is available. If it is not yet
+available, run apply again later to install them.`
+}
+
+var planFieldNames = []string{"ACTION", "GROUP", "NAME", "CHANGES"}
+
+// Run executes the apply command.
+func (c *applyCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	b, err := os.ReadFile(c.File)
+	if err != nil {
+		return errors.Wrap(err, errReadFleet)
+	}
+	f, err := fleet.Parse(b)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	existing, err := fleet.List(ctx, c.dClient)
+	if err != nil {
+		return err
+	}
+	changes, err := fleet.Plan(f, existing)
+	if err != nil {
+		return err
+	}
+	if err := printer.Print(changes, planFieldNames, extractPlanFields); err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, ch := range changes {
+		if ch.Action != fleet.ActionNone {
+			pending++
+		}
+	}
+	if pending == 0 {
+		p.Printfln("No changes. The control planes match the fleet.")
+		return nil
+	}
+	if c.DryRun {
+		return nil
+	}
+	if !c.Yes {
+		pterm.DefaultInteractiveConfirm.DefaultText = "Would you like to apply the plan?"
+		pterm.Println() // Blank line
+		result, _ := pterm.DefaultInteractiveConfirm.Show()
+		pterm.Println() // Blank line
+		if !result {
+			return nil
+		}
+	}
+
+	a := fleet.NewApplier(c.dClient, f.Name)
+	for _, ch := range changes {
+		if ch.Action == fleet.ActionNone {
+			continue
+		}
+		done, err := a.Apply(ctx, ch)
+		if err != nil {
+			return err
+		}
+		p.Printfln("%s/%s: %s", ch.Group, ch.Name, ch.Action)
+		if !done {
+			pterm.Warning.Printfln("%s/%s is not available yet, run apply again to reconcile its packages", ch.Group, ch.Name)
+		}
+	}
+	return nil
+}
+
+func extractPlanFields(obj any) []string {
+	c := obj.(fleet.Change)
+	return []string{string(c.Action), c.Group, c.Name, strings.Join(c.Diffs, ", ")}
+}
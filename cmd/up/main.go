@@ -15,28 +15,43 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/pterm/pterm"
 	"github.com/willabides/kongplete"
+	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/upbound/up/cmd/up/audit"
 	"github.com/upbound/up/cmd/up/configuration"
 	"github.com/upbound/up/cmd/up/configuration/template"
 	"github.com/upbound/up/cmd/up/controlplane"
+	"github.com/upbound/up/cmd/up/ctx"
+	"github.com/upbound/up/cmd/up/fleet"
+	"github.com/upbound/up/cmd/up/migration"
 	"github.com/upbound/up/cmd/up/organization"
 	"github.com/upbound/up/cmd/up/profile"
+	"github.com/upbound/up/cmd/up/query"
 	"github.com/upbound/up/cmd/up/repository"
 	"github.com/upbound/up/cmd/up/robot"
 	"github.com/upbound/up/cmd/up/space"
+	"github.com/upbound/up/cmd/up/stats"
+	"github.com/upbound/up/cmd/up/supportbundle"
+	"github.com/upbound/up/cmd/up/test"
 	"github.com/upbound/up/cmd/up/upbound"
 	"github.com/upbound/up/cmd/up/uxp"
+	"github.com/upbound/up/cmd/up/validate"
 	"github.com/upbound/up/cmd/up/xpkg"
 	"github.com/upbound/up/cmd/up/xpls"
 	"github.com/upbound/up/internal/config"
 	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/plugin"
+	"github.com/upbound/up/internal/tracing"
 	"github.com/upbound/up/internal/upterm"
 	"github.com/upbound/up/internal/version"
 
@@ -47,6 +62,13 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
 
+const (
+	// themeConfigKey is the profile setting that selects the output theme.
+	themeConfigKey = "output.theme"
+
+	traceFlushTimeout = 5 * time.Second
+)
+
 type versionFlag bool
 
 // BeforeApply indicates that we want to execute the logic before running any
@@ -71,6 +93,32 @@ func (c *cli) AfterApply(ctx *kong.Context) error { //nolint:unparam
 		pterm.DisableStyling()
 	}
 
+	if c.Theme != "" {
+		if err := upterm.SetTheme(c.Theme); err != nil {
+			return err
+		}
+	} else if err := upterm.SetTheme(profileTheme()); err != nil {
+		// An invalid profile setting must not prevent correcting it.
+		pterm.Warning.WithWriter(ctx.Stderr).Printfln("Ignoring %s setting of profile: %s", themeConfigKey, err)
+		_ = upterm.SetTheme(upterm.ThemeDark)
+	}
+
+	upterm.SetProgress(c.Progress, ctx.Stderr)
+
+	if c.Offline {
+		if err := feature.CheckOffline(ctx); err != nil {
+			return err
+		}
+	}
+	ctx.Bind(c.Offline)
+
+	if c.ReadOnly {
+		if err := feature.CheckReadOnly(ctx); err != nil {
+			return err
+		}
+	}
+	ctx.Bind(c.ReadOnly)
+
 	printer := upterm.DefaultObjPrinter
 	printer.Format = c.Format
 	printer.Pretty = c.Pretty
@@ -81,6 +129,50 @@ func (c *cli) AfterApply(ctx *kong.Context) error { //nolint:unparam
 	return nil
 }
 
+// loadConfig reads the up config file without creating it. It returns nil if
+// the config cannot be read.
+func loadConfig() *config.Config {
+	p, err := config.GetDefaultPath()
+	if err != nil {
+		return nil
+	}
+	cfg, err := config.NewFSSource(config.WithPath(p)).GetConfig()
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// isBuiltin returns true if the supplied name is a command or alias of a
+// command of the supplied node.
+func isBuiltin(n *kong.Node, name string) bool {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return true
+		}
+		for _, a := range c.Aliases {
+			if a == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// profileTheme returns the theme configured with the output.theme setting of
+// the default profile, if any.
+func profileTheme() upterm.Theme {
+	cfg := loadConfig()
+	if cfg == nil {
+		return ""
+	}
+	_, prof, err := cfg.GetDefaultUpboundProfile()
+	if err != nil {
+		return ""
+	}
+	return upterm.Theme(prof.BaseConfig[themeConfigKey])
+}
+
 // BeforeReset runs before all other hooks. Default maturity level is stable.
 func (c *cli) BeforeReset(ctx *kong.Context, p *kong.Path) error {
 	ctx.Bind(feature.Stable)
@@ -96,24 +188,39 @@ type cli struct {
 	Version versionFlag      `short:"v" name:"version" help:"Print version and exit."`
 	Quiet   config.QuietFlag `short:"q" name:"quiet" help:"Suppress all output."`
 	Pretty  bool             `name:"pretty" help:"Pretty print output."`
+	Theme   upterm.Theme     `name:"theme" env:"UP_THEME" help:"Theme used to pretty print output. Can be: plain, dark, light, colorblind. Defaults to the output.theme setting of the profile."`
+
+	Progress upterm.ProgressMode `name:"progress" enum:"auto,spinner,plain,json" default:"auto" env:"UP_PROGRESS" help:"How to print the progress of long running steps. Can be: auto, spinner, plain, json"`
+
+	AuditLog bool `name:"audit-log" env:"UP_AUDIT_LOG" help:"Record mutating commands in a local audit log."`
+
+	Offline feature.Offline `name:"offline" env:"UP_OFFLINE" help:"Operate from local caches without network access. Commands that require network access fail."`
 
-	License licenseCmd `cmd:"" help:"Print Up license information."`
+	ReadOnly feature.ReadOnly `name:"read-only" env:"UP_READ_ONLY" help:"Refuse to run commands that change state, e.g. on shared hosts where only inspection is allowed."`
 
-	Help               helpCmd                      `cmd:"" help:"Show help."`
+	License licenseCmd `cmd:"" offline:"" help:"Print Up license information."`
+
+	Help               helpCmd                      `cmd:"" offline:"" help:"Show help."`
 	Login              loginCmd                     `cmd:"" help:"Login to Upbound."`
 	Logout             logoutCmd                    `cmd:"" help:"Logout of Upbound."`
+	API                apiCmd                       `cmd:"" name:"api" help:"Make an authenticated request to the Upbound API."`
 	Configuration      configuration.Cmd            `cmd:"" name:"configuration" aliases:"cfg" help:"Interact with configurations."`
 	ControlPlane       controlplane.Cmd             `cmd:"" name:"controlplane" aliases:"ctp" help:"Interact with control planes."`
+	Get                query.Cmd                    `cmd:"" help:"Get resources inside a control plane."`
 	Organization       organization.Cmd             `cmd:"" name:"organization" aliases:"org" help:"Interact with organizations."`
-	Profile            profile.Cmd                  `cmd:"" help:"Interact with Upbound profiles."`
+	Profile            profile.Cmd                  `cmd:"" offline:"" help:"Interact with Upbound profiles."`
+	Ctx                ctx.Cmd                      `cmd:"" name:"ctx" offline:"" help:"Save and switch between context bookmarks."`
 	Repository         repository.Cmd               `cmd:"" name:"repository" aliases:"repo" help:"Interact with repositories."`
 	Robot              robot.Cmd                    `cmd:"" name:"robot" help:"Interact with robots."`
 	UXP                uxp.Cmd                      `cmd:"" help:"Interact with UXP."`
 	XPKG               xpkg.Cmd                     `cmd:"" help:"Interact with UXP packages."`
-	XPLS               xpls.Cmd                     `cmd:"" help:"Start xpls language server."`
+	XPLS               xpls.Cmd                     `cmd:"" offline:"" help:"Start xpls language server."`
 	Alpha              alpha                        `cmd:"" help:"Alpha features. Commands may be removed in future releases."`
-	InstallCompletions kongplete.InstallCompletions `cmd:"" help:"Install shell completions"`
+	Audit              audit.Cmd                    `cmd:"" offline:"" help:"Inspect the local audit log."`
+	Plugin             pluginCmd                    `cmd:"" offline:"" help:"Interact with up plugins."`
+	InstallCompletions kongplete.InstallCompletions `cmd:"" offline:"" help:"Install shell completions"`
 	Space              space.Cmd                    `cmd:"" help:"Interact with spaces."`
+	Test               test.Cmd                     `cmd:"" help:"Test Compositions."`
 }
 
 type helpCmd struct{}
@@ -133,9 +240,14 @@ func (a *alpha) BeforeReset(ctx *kong.Context) error { //nolint:unparam
 type alpha struct {
 	// For now, we maintain compatibility for systems that may still use the alpha variant.
 	// This nudges users towards the stable variant when they attempt to emit help.
-	ControlPlane controlplane.Cmd `cmd:"" hidden:"" name:"controlplane" aliases:"ctp" help:"Interact with control planes."`
-	Upbound      upbound.Cmd      `cmd:"" maturity:"alpha" help:"Interact with Upbound."`
-	XPKG         xpkg.Cmd         `cmd:"" maturity:"alpha" help:"Interact with UXP packages."`
+	ControlPlane  controlplane.Cmd  `cmd:"" hidden:"" name:"controlplane" aliases:"ctp" help:"Interact with control planes."`
+	Upbound       upbound.Cmd       `cmd:"" maturity:"alpha" help:"Interact with Upbound."`
+	Migration     migration.Cmd     `cmd:"" maturity:"alpha" help:"Migrate control planes to Upbound managed control planes."`
+	Fleet         fleet.Cmd         `cmd:"" maturity:"alpha" help:"Declaratively manage fleets of control planes in a Space."`
+	Stats         stats.Cmd         `cmd:"" maturity:"alpha" help:"Summarize the objects of a control plane."`
+	SupportBundle supportbundle.Cmd `cmd:"" name:"support-bundle" maturity:"alpha" help:"Collect diagnostic information about a control plane for Upbound support."`
+	Validate      validate.Cmd      `cmd:"" maturity:"alpha" offline:"" help:"Validate compositions against the schemas of provider packages."`
+	XPKG          xpkg.Cmd          `cmd:"" maturity:"alpha" help:"Interact with UXP packages."`
 }
 
 func main() {
@@ -173,7 +285,57 @@ func main() {
 		return
 	}
 
+	// Commands that are not built in are run as plugins if one is found. Global
+	// flags given before the name of a plugin are passed to it in the
+	// environment.
+	if name, args, vars, ok := plugin.Split(os.Args[1:], pluginFlags(parser.Model.Node)); ok && !isBuiltin(parser.Model.Node, name) {
+		if path, err := plugin.Find(name); err == nil {
+			environ := append(os.Environ(), vars...)
+			parser.FatalIfErrorf(checkPlugin(name, environ, pluginFlags(parser.Model.Node)))
+			code, err := plugin.Run(path, args, plugin.Env(environ, loadConfig(), loadKubeconfig()))
+			parser.FatalIfErrorf(err)
+			os.Exit(code)
+		}
+	}
+
 	ctx, err := parser.Parse(os.Args[1:])
 	parser.FatalIfErrorf(err)
-	ctx.FatalIfErrorf(ctx.Run())
+
+	shutdown, err := tracing.Setup(context.Background())
+	parser.FatalIfErrorf(err)
+	span := tracing.StartCommand("up "+ctx.Command(), commandAttributes(ctx)...)
+	err = ctx.Run()
+	tracing.End(span, err)
+	flushTraces(shutdown)
+
+	if c.AuditLog {
+		recordAudit(ctx, err)
+	}
+	ctx.FatalIfErrorf(err)
+}
+
+// commandAttributes returns the span attributes of the command run in the
+// supplied context: its subcommands and the flags it was run with. Flag values
+// are redacted as in the audit log, and positional arguments are not recorded.
+func commandAttributes(ctx *kong.Context) []attribute.KeyValue {
+	cmd, _, flags := invocation(ctx)
+	fs := make([]string, 0, len(flags))
+	for k, v := range flags {
+		fs = append(fs, k+"="+v)
+	}
+	sort.Strings(fs)
+	return []attribute.KeyValue{
+		attribute.StringSlice("up.command", cmd),
+		attribute.StringSlice("up.flags", fs),
+	}
+}
+
+// flushTraces exports pending spans, giving up after a short while so that an
+// unreachable collector does not block the command from exiting.
+func flushTraces(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		pterm.Warning.WithWriter(os.Stderr).Printfln("Unable to export traces: %s", err)
+	}
 }
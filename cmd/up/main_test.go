@@ -0,0 +1,97 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/upbound/up/internal/feature"
+)
+
+func TestCommandAttributes(t *testing.T) {
+	var c struct {
+		Create struct {
+			Name  string `arg:""`
+			Token string
+			Group string
+		} `cmd:""`
+	}
+	parser, err := kong.New(&c)
+	if err != nil {
+		t.Fatalf("kong.New(...): %v", err)
+	}
+	ctx, err := parser.Parse([]string{"create", "secret-name", "--token", "s3cret", "--group", "default"})
+	if err != nil {
+		t.Fatalf("Parse(...): %v", err)
+	}
+	want := []attribute.KeyValue{
+		attribute.StringSlice("up.command", []string{"create"}),
+		attribute.StringSlice("up.flags", []string{"group=default", "token=<redacted>"}),
+	}
+	if diff := cmp.Diff(want, commandAttributes(ctx), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Errorf("\ncommandAttributes(...): sensitive flag values and positional arguments should not be recorded: -want, +got:\n%s", diff)
+	}
+}
+
+func TestCheckPlugin(t *testing.T) {
+	var c struct {
+		Offline  feature.Offline  `name:"offline" env:"UP_OFFLINE"`
+		ReadOnly feature.ReadOnly `name:"read-only" env:"UP_READ_ONLY"`
+	}
+	parser, err := kong.New(&c)
+	if err != nil {
+		t.Fatalf("kong.New(...): %v", err)
+	}
+	flags := pluginFlags(parser.Model.Node)
+
+	cases := map[string]struct {
+		reason  string
+		environ []string
+		want    error
+	}{
+		"Default": {
+			reason:  "Plugins should run if neither read-only nor offline mode is enabled.",
+			environ: []string{"UP_READ_ONLY=false"},
+		},
+		"ReadOnly": {
+			reason:  "Plugins should be refused in read-only mode.",
+			environ: []string{"UP_READ_ONLY=true"},
+			want:    feature.ReadOnlyError("up foo"),
+		},
+		"ReadOnlyFlag": {
+			reason:  "A --read-only flag before the plugin name should override the environment.",
+			environ: []string{"UP_READ_ONLY=false", "UP_READ_ONLY=true"},
+			want:    feature.ReadOnlyError("up foo"),
+		},
+		"Offline": {
+			reason:  "Plugins should be refused in offline mode.",
+			environ: []string{"UP_OFFLINE=1"},
+			want:    feature.OfflineError("up foo"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkPlugin("foo", tc.environ, flags)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncheckPlugin(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
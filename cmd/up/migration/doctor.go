@@ -0,0 +1,105 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/migration"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errNotReady = "control plane is not ready to be migrated"
+)
+
+var doctorFieldNames = []string{"CHECK", "SEVERITY", "RESOURCE", "MESSAGE"}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *doctorCmd) AfterApply() error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.doctor = migration.NewDoctor(dClient, kClient)
+	return nil
+}
+
+// doctorCmd inspects a source control plane and reports issues that should
+// be resolved before it is exported.
+type doctorCmd struct {
+	doctor *migration.Doctor
+
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+// Run executes the doctor command.
+func (c *doctorCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	findings, err := c.doctor.Run(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		p.Println("No issues found. The control plane is ready to be migrated.")
+		return nil
+	}
+	if err := printer.Print(findings, doctorFieldNames, extractDoctorFields); err != nil {
+		return err
+	}
+
+	blocking := 0
+	remediations := map[string]string{}
+	checks := []string{}
+	for _, f := range findings {
+		if f.Severity == migration.SeverityError {
+			blocking++
+		}
+		if _, ok := remediations[f.Check]; !ok {
+			checks = append(checks, f.Check)
+			remediations[f.Check] = f.Remediation
+		}
+	}
+	if printer.Format == config.Default {
+		p.Println()
+		p.Println("Remediation steps:")
+		for _, check := range checks {
+			p.Printfln("  %s: %s", check, remediations[check])
+		}
+	}
+	if blocking > 0 {
+		return errors.New(errNotReady)
+	}
+	return nil
+}
+
+func extractDoctorFields(obj any) []string {
+	f := obj.(migration.Finding)
+	return []string{f.Check, string(f.Severity), f.Resource, f.Message}
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"time"
+
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/migration"
+	"github.com/upbound/up/internal/upterm"
+)
+
+var verifyFieldNames = []string{"RESOURCE", "REASON", "MESSAGE"}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *verifyActivationCmd) AfterApply() error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.verifier = migration.NewVerifier(dClient, migration.WithCategories(c.Category...))
+	return nil
+}
+
+// verifyActivationCmd waits for the resources of a migrated control plane to
+// become ready.
+type verifyActivationCmd struct {
+	verifier *migration.Verifier
+
+	Category   []string      `default:"claim,composite" help:"Categories of the resources that must become ready."`
+	Timeout    time.Duration `default:"10m" help:"How long to wait for all resources to become ready."`
+	Kubeconfig string        `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+func (c *verifyActivationCmd) Help() string {
+	return `
+The verify-activation command waits for all resources of the given categories,
+by default all claims and composite resources, in the target control plane to
+report Ready=True. If the timeout expires first, the resources that are not
+ready are listed and the command fails. Use it as the final gate of a migration
+runbook after the migrated resources have been unpaused.`
+}
+
+// Run executes the verify-activation command.
+func (c *verifyActivationCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	var total int
+	var unready []migration.UnreadyResource
+	wait := func() error {
+		var err error
+		total, unready, err = c.verifier.Wait(context.Background(), c.Timeout)
+		return err
+	}
+	err := upterm.WrapWithSuccessSpinner("Waiting for resources to become ready", upterm.CheckmarkSuccessSpinner, wait)
+	if len(unready) > 0 {
+		p.Printfln("%d of %d resources are not ready:", len(unready), total)
+		if perr := printer.Print(unready, verifyFieldNames, extractVerifyFields); perr != nil {
+			return perr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	p.Printfln("All %d resources are ready.", total)
+	return nil
+}
+
+func extractVerifyFields(obj any) []string {
+	u := obj.(migration.UnreadyResource)
+	return []string{u.Resource, u.Reason, u.Message}
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package organization
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/upbound/up-sdk-go/service/common"
+	cp "github.com/upbound/up-sdk-go/service/controlplanes"
+	"github.com/upbound/up-sdk-go/service/organizations"
+)
+
+const (
+	// controlPlanePageSize is the number of control planes requested per
+	// page.
+	controlPlanePageSize = 100
+
+	outputJSON = "json"
+
+	warnFmtIncomplete = "warning: the inventory includes %d of %d control planes as some were deleted while listing them\n"
+)
+
+var inventoryFieldNames = []string{"organization", "name", "id", "status", "configuration", "configurationVersion", "createdAt", "owner"}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *inventoryCmd) AfterApply(kongCtx *kong.Context) error {
+	c.stdout = kongCtx.Stdout
+	c.stderr = kongCtx.Stderr
+	return nil
+}
+
+// inventoryCmd exports an inventory of the control planes of an organization.
+type inventoryCmd struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	Name   string `arg:"" required:"" help:"Name of organization." predictor:"orgs"`
+	Output string `short:"o" enum:"csv,json" default:"csv" help:"Format of the inventory. Can be: csv, json"`
+}
+
+func (c *inventoryCmd) Help() string {
+	return `
+The inventory command prints a record for each control plane of an
+organization with its status, configuration and configuration version, the
+time it was created, and the username of its creator.`
+}
+
+// inventoryItem is a control plane in the inventory of an organization.
+type inventoryItem struct {
+	Organization         string     `json:"organization"`
+	Name                 string     `json:"name"`
+	ID                   string     `json:"id"`
+	Status               string     `json:"status"`
+	Configuration        string     `json:"configuration,omitempty"`
+	ConfigurationVersion string     `json:"configurationVersion,omitempty"`
+	CreatedAt            *time.Time `json:"createdAt,omitempty"`
+	Owner                string     `json:"owner,omitempty"`
+}
+
+// Run executes the inventory command.
+func (c *inventoryCmd) Run(oc *organizations.Client, cc *cp.Client) error {
+	ctx := context.Background()
+	id, err := oc.GetOrgID(ctx, c.Name)
+	if err != nil {
+		return err
+	}
+	members, err := oc.ListMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+	owners := make(map[uint]string, len(members))
+	for _, m := range members {
+		owners[m.User.ID] = m.User.Username
+	}
+	ctps, total, err := listAll(ctx, cc.List, c.Name)
+	if err != nil {
+		return err
+	}
+	if len(ctps) < total {
+		fmt.Fprintf(c.stderr, warnFmtIncomplete, len(ctps), total)
+	}
+
+	items := make([]inventoryItem, len(ctps))
+	for i, r := range ctps {
+		ctp := r.ControlPlane
+		items[i] = inventoryItem{
+			Organization: c.Name,
+			Name:         ctp.Name,
+			ID:           ctp.ID.String(),
+			Status:       string(r.Status),
+			CreatedAt:    ctp.CreatedAt,
+			Owner:        owners[ctp.CreatorID],
+		}
+		if ctp.Configuration.Name != nil {
+			items[i].Configuration = *ctp.Configuration.Name
+		}
+		if ctp.Configuration.CurrentVersion != nil {
+			items[i].ConfigurationVersion = *ctp.Configuration.CurrentVersion
+		}
+		if items[i].Owner == "" && ctp.CreatorID != 0 {
+			// The creator is no longer a member of the organization.
+			items[i].Owner = strconv.Itoa(int(ctp.CreatorID))
+		}
+	}
+
+	if c.Output == outputJSON {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+	return writeInventoryCSV(c.stdout, items)
+}
+
+// listFn lists a page of the control planes of an account.
+type listFn func(ctx context.Context, account string, opts ...common.ListOption) (*cp.ControlPlaneListResponse, error)
+
+// listAll lists the control planes of an account page by page. It returns the
+// control planes and the total number reported by the API, which is larger
+// than the number returned if control planes were deleted while listing.
+func listAll(ctx context.Context, list listFn, account string) ([]cp.ControlPlaneResponse, int, error) {
+	res, err := list(ctx, account, common.WithSize(controlPlanePageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	ctps, total := res.ControlPlanes, res.Count
+	for page := res.Page + 1; len(ctps) < total; page++ {
+		res, err := list(ctx, account, common.WithSize(controlPlanePageSize), common.WithPage(page))
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(res.ControlPlanes) == 0 {
+			break
+		}
+		ctps = append(ctps, res.ControlPlanes...)
+	}
+	return ctps, total, nil
+}
+
+func writeInventoryCSV(w io.Writer, items []inventoryItem) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(inventoryFieldNames); err != nil {
+		return err
+	}
+	for _, i := range items {
+		created := ""
+		if i.CreatedAt != nil {
+			created = i.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		if err := cw.Write([]string{i.Organization, i.Name, i.ID, i.Status, i.Configuration, i.ConfigurationVersion, created, i.Owner}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
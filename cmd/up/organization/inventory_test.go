@@ -0,0 +1,96 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package organization
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/upbound/up-sdk-go/service/common"
+	cp "github.com/upbound/up-sdk-go/service/controlplanes"
+)
+
+// pagedList returns a listFn serving the supplied control plane names in
+// pages, starting with page 1, and reporting the supplied total count.
+func pagedList(names []string, count int) listFn {
+	return func(_ context.Context, _ string, opts ...common.ListOption) (*cp.ControlPlaneListResponse, error) {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.upbound.io", nil)
+		for _, o := range opts {
+			o(req)
+		}
+		size, _ := strconv.Atoi(req.URL.Query().Get(common.SizeParam))
+		page := 1
+		if p := req.URL.Query().Get(common.PageParam); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		res := &cp.ControlPlaneListResponse{Page: page, Size: size, Count: count}
+		for i := (page - 1) * size; i < len(names) && i < page*size; i++ {
+			res.ControlPlanes = append(res.ControlPlanes, cp.ControlPlaneResponse{ControlPlane: cp.ControlPlane{Name: names[i]}})
+		}
+		return res, nil
+	}
+}
+
+func TestListAll(t *testing.T) {
+	many := make([]string, 2*controlPlanePageSize+1)
+	for i := range many {
+		many[i] = "ctp-" + strconv.Itoa(i)
+	}
+
+	type want struct {
+		names []string
+		total int
+	}
+	cases := map[string]struct {
+		reason string
+		list   listFn
+		want   want
+	}{
+		"SinglePage": {
+			reason: "Control planes fitting in a single page should be listed with one request.",
+			list:   pagedList([]string{"a", "b"}, 2),
+			want:   want{names: []string{"a", "b"}, total: 2},
+		},
+		"ManyPages": {
+			reason: "All pages should be listed until the total count is reached.",
+			list:   pagedList(many, len(many)),
+			want:   want{names: many, total: len(many)},
+		},
+		"Deleted": {
+			reason: "Listing should stop at an empty page and report the total count.",
+			list:   pagedList(many[:controlPlanePageSize], controlPlanePageSize+1),
+			want:   want{names: many[:controlPlanePageSize], total: controlPlanePageSize + 1},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctps, total, err := listAll(context.Background(), tc.list, "acme")
+			if err != nil {
+				t.Fatalf("\n%s\nlistAll(...): %v", tc.reason, err)
+			}
+			names := make([]string, len(ctps))
+			for i := range ctps {
+				names[i] = ctps[i].ControlPlane.Name
+			}
+			if diff := cmp.Diff(tc.want, want{names: names, total: total}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nlistAll(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
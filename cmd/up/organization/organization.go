@@ -20,6 +20,7 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/posener/complete"
 
+	cp "github.com/upbound/up-sdk-go/service/controlplanes"
 	"github.com/upbound/up-sdk-go/service/organizations"
 
 	"github.com/upbound/up/cmd/up/organization/user"
@@ -39,6 +40,7 @@ func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
 	}
 	kongCtx.Bind(upCtx)
 	kongCtx.Bind(organizations.NewClient(cfg))
+	kongCtx.Bind(cp.NewClient(cfg))
 	return nil
 }
 
@@ -82,6 +84,8 @@ type Cmd struct {
 	List   listCmd   `cmd:"" help:"List organizations."`
 	Get    getCmd    `cmd:"" help:"Get an organization."`
 
+	Inventory inventoryCmd `cmd:"" help:"Export an inventory of the control planes of an organization."`
+
 	User user.Cmd `cmd:"" help:"Manage organization users."`
 
 	// Common Upbound API configuration
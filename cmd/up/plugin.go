@@ -0,0 +1,129 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/plugin"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+// pluginCmd contains commands for up plugins.
+type pluginCmd struct {
+	List pluginListCmd `cmd:"" help:"List the plugins found on the PATH."`
+}
+
+func (c *pluginCmd) Help() string {
+	return `
+Plugins extend up with additional subcommands. Any executable on the PATH whose
+name starts with "up-" is a plugin: an executable named up-foo is run as
+"up foo", with all further arguments passed to it. Built-in commands cannot be
+overridden by plugins.
+
+Plugins are refused with --read-only and --offline, since up cannot tell
+whether they change state or require network access.
+
+Plugins are run with UP_PROFILE and UP_ACCOUNT set to the profile and account
+in use, and UP_KUBE_CONTEXT and UP_GROUP set to the current kubeconfig context
+and its namespace, unless they are set already. Global flags that can be set by
+an environment variable, e.g. --profile, --account or --read-only, may be given
+before the name of the plugin and are passed to it in that variable:
+
+  up --profile prod foo`
+}
+
+// pluginFlags returns the flags that may be given before the name of a plugin:
+// the global flags of the supplied node and the Upbound flags, e.g. --profile.
+// Only flags that can be set by an environment variable are returned, as that
+// is how their values are passed to the plugin.
+func pluginFlags(n *kong.Node) map[string]plugin.Flag {
+	var uf struct {
+		Flags upbound.Flags `embed:""`
+	}
+	fs := append([]*kong.Flag{}, n.Flags...)
+	fs = append(fs, kong.Must(&uf).Model.Node.Flags...)
+
+	flags := map[string]plugin.Flag{}
+	for _, f := range fs {
+		if len(f.Envs) == 0 || f.IsCounter() {
+			continue
+		}
+		pf := plugin.Flag{Env: f.Envs[0], Bool: f.IsBool()}
+		flags["--"+f.Name] = pf
+		if f.Short != 0 {
+			flags["-"+string(f.Short)] = pf
+		}
+	}
+	return flags
+}
+
+// checkPlugin returns an error if read-only or offline mode is enabled in the
+// supplied environment, which includes the flags given before the name of the
+// plugin. up cannot tell whether a plugin changes state or needs network
+// access, so plugins are refused in both modes.
+func checkPlugin(name string, environ []string, flags map[string]plugin.Flag) error {
+	env := map[string]string{}
+	for _, e := range environ {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			env[k] = v
+		}
+	}
+	if ro, _ := strconv.ParseBool(env[flags["--read-only"].Env]); ro {
+		return feature.ReadOnlyError("up " + name)
+	}
+	if off, _ := strconv.ParseBool(env[flags["--offline"].Env]); off {
+		return feature.OfflineError("up " + name)
+	}
+	return nil
+}
+
+// loadKubeconfig returns the kubeconfig in use, or nil if it cannot be loaded.
+func loadKubeconfig() *api.Config {
+	conf, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		return nil
+	}
+	return conf
+}
+
+// pluginListCmd lists the plugins found on the PATH.
+type pluginListCmd struct{}
+
+var pluginFieldNames = []string{"NAME", "PATH", "SHADOWED"}
+
+// Run executes the plugin list command.
+func (c *pluginListCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	plugins := plugin.List(os.Getenv("PATH"))
+	if len(plugins) == 0 {
+		p.Println("No plugins found")
+		return nil
+	}
+	return printer.Print(plugins, pluginFieldNames, extractPluginFields)
+}
+
+func extractPluginFields(obj any) []string {
+	pl := obj.(plugin.Plugin)
+	return []string{pl.Name, pl.Path, strconv.FormatBool(pl.Shadowed)}
+}
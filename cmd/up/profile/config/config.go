@@ -16,6 +16,6 @@ package config
 
 // Cmd contains commands for configuring Upbound Profiles.
 type Cmd struct {
-	Set   setCmd   `cmd:"" help:"Set base configuration key, value pair in the Upbound Profile."`
-	UnSet unsetCmd `cmd:"" name:"unset" help:"Unset base configuration key, value pair in the Upbound Profile."`
+	Set   setCmd   `cmd:"" mutating:"" help:"Set base configuration key, value pair in the Upbound Profile."`
+	UnSet unsetCmd `cmd:"" name:"unset" mutating:"" help:"Unset base configuration key, value pair in the Upbound Profile."`
 }
@@ -0,0 +1,85 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	errWriteBundle = "unable to write profile bundle"
+)
+
+type exportCmd struct {
+	Names          []string `arg:"" optional:"" help:"Names of the Profiles to export. Defaults to all Profiles." predictor:"profiles"`
+	WithoutSecrets bool     `help:"Omit IDs, sessions and registry credentials so that the bundle can be shared."`
+	Output         string   `short:"o" type:"path" help:"Path to write the bundle to. Defaults to stdout."`
+}
+
+func (c *exportCmd) Help() string {
+	return `
+The export command writes the supplied Profiles, or all Profiles, to a JSON
+bundle that can be imported with "up profile import". With --without-secrets
+the bundle contains only the profile type, account and persisted settings such
+as the Upbound domain and registry endpoints, so that teams can distribute a
+standard configuration without sharing credentials.`
+}
+
+// Run executes the export command.
+func (c *exportCmd) Run(kongCtx *kong.Context, upCtx *upbound.Context) error {
+	profiles, err := upCtx.Cfg.GetUpboundProfiles()
+	if err != nil {
+		return err
+	}
+	names := c.Names
+	if len(names) == 0 {
+		for n := range profiles {
+			names = append(names, n)
+		}
+	}
+
+	b := config.ProfileBundle{Profiles: make(map[string]config.Profile, len(names))}
+	for _, n := range names {
+		p, err := upCtx.Cfg.GetUpboundProfile(n)
+		if err != nil {
+			return err
+		}
+		if c.WithoutSecrets {
+			p = p.WithoutSecrets()
+		}
+		b.Profiles[n] = p
+	}
+
+	var w io.Writer = kongCtx.Stdout
+	if c.Output != "" {
+		f, err := os.OpenFile(c.Output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return errors.Wrap(err, errWriteBundle)
+		}
+		defer f.Close() //nolint:errcheck,gosec
+		w = f
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "    ")
+	return errors.Wrap(e.Encode(b), errWriteBundle)
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	errReadBundle  = "unable to read profile bundle"
+	errEmptyBundle = "profile bundle contains no profiles"
+)
+
+type importCmd struct {
+	File string `arg:"" required:"" type:"existingfile" help:"Path to a profile bundle created with \"up profile export\"."`
+}
+
+func (c *importCmd) Help() string {
+	return `
+The import command adds the Profiles of a bundle created with "up profile
+export" to the local configuration. Settings of an existing Profile with the
+same name are merged with the imported ones, and its credentials are kept
+unless the bundle contains credentials. If no Profile is in use yet, the first
+imported Profile is used. Profiles imported without credentials
+can be authenticated with "up login --profile <name>".`
+}
+
+// Run executes the import command.
+func (c *importCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
+	data, err := os.ReadFile(c.File)
+	if err != nil {
+		return errors.Wrap(err, errReadBundle)
+	}
+	b := config.ProfileBundle{}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return errors.Wrap(err, errReadBundle)
+	}
+	if len(b.Profiles) == 0 {
+		return errors.New(errEmptyBundle)
+	}
+
+	names := make([]string, 0, len(b.Profiles))
+	for n := range b.Profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		upCtx.Cfg.ImportUpboundProfile(n, b.Profiles[n])
+	}
+	// Use the first imported profile if none is in use yet.
+	if upCtx.Cfg.Upbound.Default == "" {
+		upCtx.Cfg.Upbound.Default = names[0]
+	}
+	if err := upCtx.CfgSrc.UpdateConfig(upCtx.Cfg); err != nil {
+		return errors.Wrap(err, errUpdateProfile)
+	}
+	for _, n := range names {
+		p.Printfln("Imported profile %s", n)
+	}
+	return nil
+}
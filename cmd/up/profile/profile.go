@@ -26,9 +26,11 @@ import (
 type Cmd struct {
 	Current currentCmd `cmd:"" help:"Get current Upbound Profile."`
 	List    listCmd    `cmd:"" help:"List Upbound Profiles."`
-	Use     useCmd     `cmd:"" help:"Set the default Upbound Profile to the given Profile."`
+	Use     useCmd     `cmd:"" mutating:"" help:"Set the default Upbound Profile to the given Profile."`
 	View    viewCmd    `cmd:"" help:"View the Upbound Profile settings across profiles."`
 	Config  config.Cmd `cmd:"" help:"Interact with the current Upbound Profile's config."`
+	Export  exportCmd  `cmd:"" help:"Export Upbound Profiles to a bundle that can be shared."`
+	Import  importCmd  `cmd:"" help:"Import Upbound Profiles from a bundle."`
 
 	Flags upbound.Flags `embed:""`
 }
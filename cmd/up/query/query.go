@@ -0,0 +1,137 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/alecthomas/kong"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/pterm/pterm"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errNameAllNamespaces = "a resource cannot be retrieved by name across all namespaces"
+)
+
+var fieldNames = []string{"NAME", "NAMESPACE", "READY", "SYNCED", "AGE"}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	kongCtx.Bind(upCtx)
+
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.ControlPlane), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	mapper, err := kube.NewDiscoveryRESTMapper(cfg)
+	if err != nil {
+		return err
+	}
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	c.mapper = mapper
+	c.client = client
+	return nil
+}
+
+// Cmd gets or lists resources inside of a control plane without requiring the
+// caller to switch kubeconfig contexts.
+type Cmd struct {
+	mapper meta.RESTMapper
+	client dynamic.Interface
+
+	Resource string `arg:"" required:"" help:"Type of resource to get, e.g. providers or compositions.apiextensions.crossplane.io."`
+	Name     string `arg:"" optional:"" help:"Name of the resource. All resources of the type are listed if omitted."`
+
+	ControlPlane  string `name:"controlplane" required:"" help:"Name of the control plane to query." predictor:"ctps"`
+	Token         string `required:"" help:"API token used to authenticate."`
+	Namespace     string `short:"n" default:"default" help:"Namespace of namespaced resources."`
+	AllNamespaces bool   `short:"A" help:"List namespaced resources across all namespaces."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+// Run executes the get command.
+func (c *Cmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	if c.AllNamespaces && c.Name != "" {
+		return errors.New(errNameAllNamespaces)
+	}
+	m, err := kube.MappingFor(c.mapper, c.Resource)
+	if err != nil {
+		return err
+	}
+
+	var ri dynamic.ResourceInterface = c.client.Resource(m.Resource)
+	if m.Scope.Name() == meta.RESTScopeNameNamespace && !c.AllNamespaces {
+		ri = c.client.Resource(m.Resource).Namespace(c.Namespace)
+	}
+
+	ctx := context.Background()
+	if c.Name != "" {
+		u, err := ri.Get(ctx, c.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		return printer.Print(u.Object, fieldNames, extractFields)
+	}
+
+	l, err := ri.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(l.Items) == 0 {
+		p.Printfln("No %s found in control plane %s", m.Resource.Resource, c.ControlPlane)
+		return nil
+	}
+	objs := make([]map[string]any, len(l.Items))
+	for i := range l.Items {
+		objs[i] = l.Items[i].Object
+	}
+	return printer.Print(objs, fieldNames, extractFields)
+}
+
+func extractFields(obj any) []string {
+	u := unstructured.Unstructured{Object: obj.(map[string]any)}
+	conditioned := xpv1.ConditionedStatus{}
+	_ = fieldpath.Pave(u.Object).GetValueInto("status", &conditioned)
+	return []string{
+		u.GetName(),
+		u.GetNamespace(),
+		string(conditioned.GetCondition(xpv1.TypeReady).Status),
+		string(conditioned.GetCondition(xpv1.TypeSynced).Status),
+		duration.HumanDuration(time.Since(u.GetCreationTimestamp().Time)),
+	}
+}
@@ -81,6 +81,7 @@ type Cmd struct {
 	Delete deleteCmd `cmd:"" help:"Delete a repository."`
 	List   listCmd   `cmd:"" help:"List repositories for the account."`
 	Get    getCmd    `cmd:"" help:"Get a repository for the account."`
+	Tag    tagCmd    `cmd:"" help:"Interact with the tags of a repository."`
 
 	// Common Upbound API configuration
 	Flags upbound.Flags `embed:""`
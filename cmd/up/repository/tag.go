@@ -0,0 +1,75 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/credhelper"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errInvalidRepository = "repository is not a valid reference"
+	errListTags          = "failed to list tags"
+)
+
+// tagCmd contains commands for interacting with repository tags.
+type tagCmd struct {
+	List tagListCmd `cmd:"" help:"List the tags of a repository."`
+}
+
+// tagListCmd lists the tags of a repository in the registry.
+type tagListCmd struct {
+	Repository string `arg:"" required:"" help:"Name of repo. Repositories in the current account may be referred to by name only." predictor:"repos"`
+}
+
+var tagFieldNames = []string{"TAG"}
+
+// Run executes the tag list command.
+func (c *tagListCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, upCtx *upbound.Context) error {
+	repo := c.Repository
+	if !strings.Contains(repo, "/") {
+		repo = upCtx.Account + "/" + repo
+	}
+	ref, err := name.NewRepository(repo, name.WithDefaultRegistry(upCtx.RegistryEndpoint.Hostname()))
+	if err != nil {
+		return errors.Wrap(err, errInvalidRepository)
+	}
+	kc := credhelper.NewKeychain(
+		credhelper.WithDomain(upCtx.Domain.Hostname()),
+		credhelper.WithProfile(upCtx.ProfileName),
+	)
+	tags, err := remote.List(ref, remote.WithAuthFromKeychain(kc), remote.WithContext(context.Background()))
+	if err != nil {
+		return errors.Wrap(err, errListTags)
+	}
+	if len(tags) == 0 {
+		p.Printfln("No tags found in %s", ref.Name())
+		return nil
+	}
+	return printer.Print(tags, tagFieldNames, extractTagFields)
+}
+
+func extractTagFields(obj any) []string {
+	return []string{obj.(string)}
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/certs"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	ns = "upbound-system"
+
+	caCommonName = "upbound-spaces-ingress-ca"
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+
+	errCAKeyTogether  = "--ca and --key must be supplied together"
+	errReadCA         = "failed to read CA certificate"
+	errReadKey        = "failed to read CA private key"
+	errLoadCA         = "failed to load CA"
+	errGenerateCA     = "failed to generate CA"
+	errGetCASecret    = "failed to get CA secret"
+	errLoadCASecret   = "failed to load CA from secret"
+	errCreateCASecret = "failed to create CA secret"
+	errFmtCAExpires   = "CA in secret %s/%s expires at %s, before the rotated certificate would; supply a new CA with --ca and --key"
+	errGetSecret      = "failed to get TLS secret"
+	errParseCurrent   = "failed to parse current TLS certificate"
+	errIssueCert      = "failed to issue TLS certificate"
+	errUpdateSecret   = "failed to update TLS secret"
+	errFmtVerify      = "failed to verify TLS connection to %s"
+	errUnexpectedLeaf = "ingress is not serving the rotated certificate"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *rotateCmd) AfterApply() error {
+	if (c.CA == "") != (c.Key == "") {
+		return errors.New(errCAKeyTogether)
+	}
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.kClient = kClient
+	return nil
+}
+
+// rotateCmd rotates the ingress TLS certificates of a Space.
+type rotateCmd struct {
+	kClient kubernetes.Interface
+
+	CA       string `type:"existingfile" help:"Path to a PEM encoded CA certificate used to sign the new certificate. Defaults to the CA stored in --ca-secret."`
+	Key      string `type:"existingfile" help:"Path to the PEM encoded private key of the CA supplied with --ca."`
+	CASecret string `default:"upbound-spaces-ingress-ca" help:"Name of the secret the CA is stored in when --ca is not supplied. A CA is generated and stored in it if it does not exist."`
+
+	Secret      string        `default:"mxe-router-tls" help:"Name of the secret holding the ingress TLS certificate."`
+	Deployments []string      `default:"mxe-router" help:"Deployments serving the ingress TLS certificate, restarted in the given order."`
+	Host        string        `help:"Host used to verify connectivity after rotation. Defaults to the first DNS name of the certificate."`
+	Timeout     time.Duration `default:"5m" help:"How long to wait for each deployment to become ready."`
+
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+func (c *rotateCmd) Help() string {
+	return `
+The rotate command issues a new TLS certificate for the ingress of a
+self-hosted Space, for the same DNS names and IP addresses as the current one.
+The certificate is signed by the CA supplied with --ca and --key. Otherwise it
+is signed by the CA stored in the --ca-secret secret, so that the trust root of
+the Space stays the same across rotations. If that secret does not exist, a CA
+is generated and stored in it. After the TLS secret has been updated, the router
+deployments are restarted one after another, and the command verifies that the
+ingress serves the new certificate.
+
+When a CA is generated or supplied for the first time, clients of the Space
+must be updated to trust the ca.crt key of the TLS secret. Anyone who can read
+the --ca-secret secret can issue certificates trusted by those clients.`
+}
+
+// Run executes the rotate command.
+func (c *rotateCmd) Run(p pterm.TextPrinter) error { //nolint:gocyclo
+	ctx := context.Background()
+
+	ca, err := c.loadCA(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	secret, err := c.kClient.CoreV1().Secrets(ns).Get(ctx, c.Secret, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, errGetSecret)
+	}
+	current, err := certs.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return errors.Wrap(err, errParseCurrent)
+	}
+	certPEM, keyPEM, err := ca.Issue(current.Subject.CommonName, current.DNSNames, current.IPAddresses, certValidity)
+	if err != nil {
+		return errors.Wrap(err, errIssueCert)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[corev1.TLSCertKey] = certPEM
+	secret.Data[corev1.TLSPrivateKeyKey] = keyPEM
+	secret.Data["ca.crt"] = ca.CertPEM
+	if _, err := c.kClient.CoreV1().Secrets(ns).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, errUpdateSecret)
+	}
+	p.Printfln("Updated TLS secret %s/%s", ns, c.Secret)
+
+	for i, d := range c.Deployments {
+		restart := func() error {
+			return kube.RolloutRestart(ctx, c.kClient, ns, d, c.Timeout)
+		}
+		if err := upterm.WrapWithSuccessSpinner(
+			upterm.StepCounter(fmt.Sprintf("Restarting %s", d), i+1, len(c.Deployments)),
+			upterm.CheckmarkSuccessSpinner,
+			restart,
+		); err != nil {
+			return err
+		}
+	}
+
+	host := c.Host
+	if host == "" && len(current.DNSNames) > 0 {
+		host = current.DNSNames[0]
+	}
+	if host == "" {
+		pterm.Warning.Println("Skipping connectivity check as no host is known. Supply one with --host.")
+		return nil
+	}
+	if err := verify(host, ca, certPEM); err != nil {
+		return errors.Wrapf(err, errFmtVerify, host)
+	}
+	p.Printfln("Verified %s is serving the rotated certificate", host)
+	return nil
+}
+
+// loadCA loads the CA supplied with --ca and --key. If none is supplied it
+// loads the CA stored in the CA secret, generating and storing one if the
+// secret does not exist.
+func (c *rotateCmd) loadCA(ctx context.Context, p pterm.TextPrinter) (*certs.CA, error) {
+	if c.CA == "" {
+		return c.storedCA(ctx, p)
+	}
+	certPEM, err := os.ReadFile(c.CA)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadCA)
+	}
+	keyPEM, err := os.ReadFile(c.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadKey)
+	}
+	ca, err := certs.LoadCA(certPEM, keyPEM)
+	return ca, errors.Wrap(err, errLoadCA)
+}
+
+func (c *rotateCmd) storedCA(ctx context.Context, p pterm.TextPrinter) (*certs.CA, error) {
+	s, err := c.kClient.CoreV1().Secrets(ns).Get(ctx, c.CASecret, metav1.GetOptions{})
+	if err == nil {
+		ca, err := certs.LoadCA(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, errors.Wrap(err, errLoadCASecret)
+		}
+		if ca.Cert.NotAfter.Before(time.Now().Add(certValidity)) {
+			return nil, errors.Errorf(errFmtCAExpires, ns, c.CASecret, ca.Cert.NotAfter.Format(time.RFC3339))
+		}
+		return ca, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, errGetCASecret)
+	}
+
+	ca, err := certs.NewCA(caCommonName, caValidity)
+	if err != nil {
+		return nil, errors.Wrap(err, errGenerateCA)
+	}
+	if _, err := c.kClient.CoreV1().Secrets(ns).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: c.CASecret},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       ca.CertPEM,
+			corev1.TLSPrivateKeyKey: ca.KeyPEM,
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		return nil, errors.Wrap(err, errCreateCASecret)
+	}
+	p.Printfln("Generated CA and stored it in secret %s/%s", ns, c.CASecret)
+	return ca, nil
+}
+
+// verify checks that the supplied host serves the rotated certificate, and
+// that the certificate is trusted by the supplied CA.
+func verify(host string, ca *certs.CA, certPEM []byte) error {
+	want, err := certs.ParseCertificate(certPEM)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	d := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(d, "tcp", net.JoinHostPort(host, "443"), &tls.Config{
+		ServerName: host,
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close() //nolint:errcheck
+	peers := conn.ConnectionState().PeerCertificates
+	if len(peers) == 0 || !peers[0].Equal(want) {
+		return errors.New(errUnexpectedLeaf)
+	}
+	return nil
+}
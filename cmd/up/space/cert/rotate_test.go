@@ -0,0 +1,63 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upbound/up/internal/certs"
+)
+
+func TestStoredCA(t *testing.T) {
+	p := pterm.DefaultBasicText.WithWriter(io.Discard)
+	ctx := context.Background()
+
+	t.Run("GenerateAndReuse", func(t *testing.T) {
+		c := &rotateCmd{kClient: fake.NewSimpleClientset(), CASecret: "ca"}
+		first, err := c.storedCA(ctx, p)
+		if err != nil {
+			t.Fatalf("storedCA(...): unexpected error: %v", err)
+		}
+		second, err := c.storedCA(ctx, p)
+		if err != nil {
+			t.Fatalf("storedCA(...): unexpected error: %v", err)
+		}
+		if !first.Cert.Equal(second.Cert) {
+			t.Errorf("storedCA(...): a generated CA should be reused by later rotations")
+		}
+	})
+
+	t.Run("Expiring", func(t *testing.T) {
+		ca, err := certs.NewCA(caCommonName, time.Hour)
+		if err != nil {
+			t.Fatalf("NewCA(...): %v", err)
+		}
+		c := &rotateCmd{kClient: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "ca"},
+			Data:       map[string][]byte{corev1.TLSCertKey: ca.CertPEM, corev1.TLSPrivateKeyKey: ca.KeyPEM},
+		}), CASecret: "ca"}
+		if _, err := c.storedCA(ctx, p); err == nil {
+			t.Errorf("storedCA(...): a CA expiring before the rotated certificate should be rejected")
+		}
+	})
+}
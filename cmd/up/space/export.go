@@ -0,0 +1,96 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/space/archive"
+)
+
+const (
+	errCreateArchive = "failed to create archive file"
+	errOpenArchive   = "failed to open archive file"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *exportCmd) AfterApply() error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
+	return nil
+}
+
+// exportCmd exports the Space-level configuration of a Space.
+type exportCmd struct {
+	dClient dynamic.Interface
+
+	Output string `short:"o" type:"path" default:"space-export.tar.gz" help:"Path of the archive to write."`
+
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+func (c *exportCmd) Help() string {
+	return `
+The export command writes the Space-level configuration of a Space to a gzipped
+tar archive: its control plane groups, and the ControlPlanes, SharedBackupConfigs,
+SharedSecretStores, SharedExternalSecrets, Roles and RoleBindings within them.
+The archive can be imported with "up space import" to recover the management
+layer of a Space.
+
+Secrets referenced by these objects, and the state inside control planes, are
+not part of the archive.`
+}
+
+// Run executes the export command.
+func (c *exportCmd) Run(p pterm.TextPrinter) error {
+	f, err := os.Create(c.Output)
+	if err != nil {
+		return errors.Wrap(err, errCreateArchive)
+	}
+	defer f.Close() //nolint:errcheck
+
+	res, err := archive.Export(context.Background(), c.dClient, f)
+	if err != nil {
+		return err
+	}
+	printCounts(p, res.Counts)
+	p.Printfln("Space exported to %s", c.Output)
+	return nil
+}
+
+func printCounts(p pterm.TextPrinter, counts map[string]int) {
+	names := make([]string, 0, len(counts))
+	for n := range counts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		p.Printfln("%s: %d", n, counts[n])
+	}
+}
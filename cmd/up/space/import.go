@@ -0,0 +1,112 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"os"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/input"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/space/archive"
+)
+
+const errImportNotConfirmed = "context name does not match, import canceled"
+
+// BeforeApply sets default values in command before assignment and validation.
+func (c *importCmd) BeforeApply() error {
+	c.prompter = input.NewPrompter()
+	return nil
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *importCmd) AfterApply() error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.context, c.server, err = kube.CurrentContext(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
+	return nil
+}
+
+// importCmd imports the Space-level configuration of a Space.
+type importCmd struct {
+	dClient  dynamic.Interface
+	prompter input.Prompter
+	context  string
+	server   string
+
+	Archive string `arg:"" type:"existingfile" help:"Path of an archive written by the export command."`
+	Yes     bool   `help:"Import without asking to confirm the target kubeconfig context."`
+
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
+
+func (c *importCmd) Help() string {
+	return `
+The import command creates the objects of an archive written by
+"up space export" in a Space. Control plane groups are created first, followed
+by RBAC, shared secrets and backup configurations, and finally control planes.
+Objects that already exist in the Space are left unchanged.
+
+Before importing, the command prints the kubeconfig context and server it
+imports into, and asks to confirm them by typing the name of the context,
+unless --yes is supplied.`
+}
+
+// Run executes the import command.
+func (c *importCmd) Run(p pterm.TextPrinter) error {
+	p.Printfln("Importing into context %q (server %s)", c.context, c.server)
+	if !c.Yes {
+		confirm, err := c.prompter.Prompt("Type the name of the context to confirm", false)
+		if err != nil {
+			return err
+		}
+		if confirm != c.context {
+			return errors.New(errImportNotConfirmed)
+		}
+	}
+
+	f, err := os.Open(c.Archive)
+	if err != nil {
+		return errors.Wrap(err, errOpenArchive)
+	}
+	defer f.Close() //nolint:errcheck
+
+	res, err := archive.Import(context.Background(), c.dClient, f)
+	if res != nil {
+		printCounts(p, res.Counts)
+		for _, s := range res.Skipped {
+			p.Printfln("Skipped %s as it already exists", s)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	p.Printfln("Space imported from %s", c.Archive)
+	return nil
+}
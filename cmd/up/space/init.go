@@ -361,23 +361,25 @@ func (c *initCmd) deploySpace(ctx context.Context, params map[string]any) error
 		return err
 	}
 
-	hcSpinner, _ := upterm.CheckmarkSuccessSpinner.Start(upterm.StepCounter("Starting Space Components", 3, 3))
-
-	errC, err := kube.DynamicWatch(ctx, c.dClient.Resource(hostclusterGVR), &watcherTimeout, func(u *unstructured.Unstructured) (bool, error) {
-		up := resources.HostCluster{Unstructured: *u}
-		if resource.IsConditionTrue(up.GetCondition(xpv1.TypeReady)) {
-			return true, nil
+	waitReady := func() error {
+		errC, err := kube.DynamicWatch(ctx, c.dClient.Resource(hostclusterGVR), &watcherTimeout, func(u *unstructured.Unstructured) (bool, error) {
+			up := resources.HostCluster{Unstructured: *u}
+			if resource.IsConditionTrue(up.GetCondition(xpv1.TypeReady)) {
+				return true, nil
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
 		}
-		return false, nil
-	})
-	if err != nil {
-		return err
+		return <-errC
 	}
-	if err := <-errC; err != nil {
-		return err
-	}
-	hcSpinner.Success()
-	return nil
+
+	return upterm.WrapWithSuccessSpinner(
+		upterm.StepCounter("Starting Space Components", 3, 3),
+		upterm.CheckmarkSuccessSpinner,
+		waitReady,
+	)
 }
 
 func outputNextSteps() {
@@ -0,0 +1,42 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/resources"
+)
+
+const errDeleteConfig = "failed to delete telemetry config"
+
+// disableCmd deletes a SharedTelemetryConfig.
+type disableCmd struct {
+	Name string `default:"default" help:"Name of the telemetry config."`
+}
+
+// Run executes the disable command.
+func (c *disableCmd) Run(p pterm.TextPrinter, cmd *Cmd, dClient dynamic.Interface) error {
+	if err := dClient.Resource(resources.SharedTelemetryConfigGVR).Namespace(cmd.Group).Delete(context.Background(), c.Name, metav1.DeleteOptions{}); err != nil {
+		return errors.Wrap(err, errDeleteConfig)
+	}
+	p.Printfln("%s/%s deleted", cmd.Group, c.Name)
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/resources"
+)
+
+const (
+	errInvalidEndpoint = "--otlp-endpoint must be an http or https URL"
+	errFmtApplyConfig  = "failed to apply telemetry config %s"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *enableCmd) AfterApply() error {
+	u, err := url.Parse(c.OTLPEndpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New(errInvalidEndpoint)
+	}
+	return nil
+}
+
+// enableCmd configures a SharedTelemetryConfig.
+type enableCmd struct {
+	OTLPEndpoint string            `name:"otlp-endpoint" required:"" help:"URL of the OTLP HTTP endpoint of the collector."`
+	Headers      map[string]string `name:"header" help:"Headers sent to the collector, e.g. for authentication."`
+	Signals      []string          `default:"logs,metrics,traces" enum:"logs,metrics,traces" help:"Signals to export. Can be: ${enum}."`
+
+	Name          string            `default:"default" help:"Name of the telemetry config. Use distinct names to override the config of specific control planes."`
+	ControlPlanes []string          `name:"controlplane" help:"Name of a control plane in the group to export telemetry from. May be repeated."`
+	Selector      map[string]string `help:"Labels of the control planes in the group to export telemetry from."`
+}
+
+func (c *enableCmd) Help() string {
+	return `
+The enable command creates or updates a SharedTelemetryConfig in the group,
+which ships the selected signals of its control planes to an OTLP collector.
+
+Telemetry is exported from all control planes of the group unless
+--controlplane or --selector are supplied. To send the telemetry of some
+control planes elsewhere, create an additional config with a distinct --name
+that selects them.`
+}
+
+// Run executes the enable command.
+func (c *enableCmd) Run(p pterm.TextPrinter, cmd *Cmd, dClient dynamic.Interface) error {
+	ctx := context.Background()
+	ri := dClient.Resource(resources.SharedTelemetryConfigGVR).Namespace(cmd.Group)
+
+	stc := &resources.SharedTelemetryConfig{}
+	u, err := ri.Get(ctx, c.Name, metav1.GetOptions{})
+	exists := err == nil
+	switch {
+	case exists:
+		stc.Unstructured = *u
+	case kerrors.IsNotFound(err):
+		stc.SetGroupVersionKind(resources.SharedTelemetryConfigGVK)
+		stc.SetName(c.Name)
+		stc.SetNamespace(cmd.Group)
+	default:
+		return errors.Wrapf(err, errFmtApplyConfig, c.Name)
+	}
+
+	var sel *metav1.LabelSelector
+	if len(c.Selector) > 0 {
+		sel = &metav1.LabelSelector{MatchLabels: c.Selector}
+	}
+	if len(c.ControlPlanes) == 0 && sel == nil {
+		sel = &metav1.LabelSelector{}
+	}
+	stc.SetControlPlaneSelector(c.ControlPlanes, sel)
+	stc.SetOTLPExporter(c.OTLPEndpoint, c.Headers, c.Signals)
+
+	if exists {
+		_, err = ri.Update(ctx, stc.GetUnstructured(), metav1.UpdateOptions{})
+	} else {
+		_, err = ri.Create(ctx, stc.GetUnstructured(), metav1.CreateOptions{})
+	}
+	if err != nil {
+		return errors.Wrapf(err, errFmtApplyConfig, c.Name)
+	}
+	p.Printfln("Telemetry of group %s is exported to %s", cmd.Group, c.OTLPEndpoint)
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"github.com/alecthomas/kong"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/kube"
+)
+
+// AfterApply constructs and binds a Kubernetes client of the Space to any
+// subcommands that have Run() methods that receive it.
+func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	kongCtx.BindTo(dClient, (*dynamic.Interface)(nil))
+	return nil
+}
+
+// Cmd contains commands for configuring the export of telemetry from the
+// control planes of a group.
+type Cmd struct {
+	Enable  enableCmd  `cmd:"" help:"Export logs, metrics and traces of control planes to an OTLP collector."`
+	Disable disableCmd `cmd:"" help:"Stop exporting telemetry of control planes."`
+	Status  statusCmd  `cmd:"" help:"Show the delivery health of telemetry export."`
+
+	Group      string `short:"g" default:"default" help:"Control plane group to configure."`
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
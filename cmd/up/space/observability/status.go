@@ -0,0 +1,67 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/resources"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const errListConfigs = "failed to list telemetry configs"
+
+// statusCmd shows the delivery health of the SharedTelemetryConfigs of a
+// group.
+type statusCmd struct{}
+
+var fieldNames = []string{"NAME", "ENDPOINT", "SELECTED", "PROVISIONED", "FAILED", "READY"}
+
+// Run executes the status command.
+func (c *statusCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, cmd *Cmd, dClient dynamic.Interface) error {
+	l, err := dClient.Resource(resources.SharedTelemetryConfigGVR).Namespace(cmd.Group).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, errListConfigs)
+	}
+	if len(l.Items) == 0 {
+		p.Printfln("Telemetry export is not enabled in group %s", cmd.Group)
+		return nil
+	}
+	configs := make([]resources.SharedTelemetryConfig, len(l.Items))
+	for i := range l.Items {
+		configs[i] = resources.SharedTelemetryConfig{Unstructured: l.Items[i]}
+	}
+	return printer.Print(configs, fieldNames, extractFields)
+}
+
+func extractFields(obj any) []string {
+	s := obj.(resources.SharedTelemetryConfig)
+	return []string{
+		s.GetName(),
+		s.GetOTLPEndpoint(),
+		strconv.Itoa(len(s.GetSelectedControlPlanes())),
+		strconv.Itoa(len(s.GetProvisionedControlPlanes())),
+		strings.Join(s.GetFailedControlPlanes(), ","),
+		string(s.GetCondition(xpv1.TypeReady).Status),
+	}
+}
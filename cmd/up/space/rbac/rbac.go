@@ -0,0 +1,51 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"github.com/alecthomas/kong"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+)
+
+// AfterApply constructs and binds a Kubernetes client and REST mapper of the
+// Space to any subcommands that have Run() methods that receive them.
+func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	mapper, err := kube.NewDiscoveryRESTMapper(kubeconfig)
+	if err != nil {
+		return err
+	}
+	kongCtx.BindTo(kClient, (*kubernetes.Interface)(nil))
+	kongCtx.BindTo(mapper, (*meta.RESTMapper)(nil))
+	return nil
+}
+
+// Cmd contains commands for inspecting access to the objects of a Space.
+type Cmd struct {
+	WhoCan whoCanCmd `cmd:"" name:"who-can" help:"Show which subjects may perform a verb on a resource."`
+
+	Group      string `short:"g" default:"default" help:"Control plane group to inspect."`
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
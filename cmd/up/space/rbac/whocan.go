@@ -0,0 +1,81 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/space/rbac"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errFmtResolveResource = "unable to resolve resource %s"
+	errEvaluateGrants     = "unable to evaluate RBAC grants"
+)
+
+// whoCanCmd shows the subjects that may perform a verb on a resource in a
+// group, and the bindings and roles that grant it.
+type whoCanCmd struct {
+	Verb     string `arg:"" required:"" help:"Verb to check, e.g. get, create or delete."`
+	Resource string `arg:"" required:"" help:"Resource to check, e.g. controlplanes, secrets or sharedexternalsecrets.spaces.upbound.io."`
+}
+
+func (c *whoCanCmd) Help() string {
+	return `
+The who-can command evaluates the Roles, ClusterRoles, RoleBindings and
+ClusterRoleBindings of a Space and prints every user, group and service
+account that may perform the supplied verb on the supplied resource in the
+group given with --group. Each line shows the binding and role that grant the
+access, so that unexpected access can be traced back to its source.
+
+Examples:
+  up space rbac who-can create controlplanes --group team-a
+  up space rbac who-can get secrets -g default`
+}
+
+var fieldNames = []string{"SUBJECT KIND", "SUBJECT", "BINDING", "ROLE"}
+
+// Run executes the who-can command.
+func (c *whoCanCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, cmd *Cmd, kClient kubernetes.Interface, mapper meta.RESTMapper) error {
+	m, err := kube.MappingFor(mapper, c.Resource)
+	if err != nil {
+		return errors.Wrapf(err, errFmtResolveResource, c.Resource)
+	}
+	grants, err := rbac.WhoCan(context.Background(), kClient, cmd.Group, c.Verb, m.Resource.GroupResource())
+	if err != nil {
+		return errors.Wrap(err, errEvaluateGrants)
+	}
+	if len(grants) == 0 {
+		p.Printfln("No subject may %s %s in group %s", c.Verb, m.Resource.GroupResource(), cmd.Group)
+		return nil
+	}
+	return printer.Print(grants, fieldNames, extractFields)
+}
+
+func extractFields(obj any) []string {
+	g := obj.(rbac.Grant)
+	name := g.Subject.Name
+	if g.Subject.Namespace != "" {
+		name = g.Subject.Namespace + "/" + name
+	}
+	return []string{g.Subject.Kind, name, g.Binding, g.Role}
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedsecret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/resources"
+)
+
+const (
+	errNoData           = "at least one of --from-literal or --from-file must be supplied"
+	errFmtInvalidPair   = "invalid key=value pair %q"
+	errFmtDuplicateKey  = "key %q supplied more than once"
+	errFmtReadFile      = "failed to read file %s"
+	errCreateSecret     = "failed to create secret"
+	errCreateSharedSecr = "failed to create shared secret"
+)
+
+// createCmd creates a shared secret.
+type createCmd struct {
+	Name string `arg:"" required:"" help:"Name of the shared secret."`
+
+	FromLiteral []string `help:"Key and literal value to add to the secret, in the form key=value. May be repeated."`
+	FromFile    []string `type:"path" help:"File to add to the secret, in the form [key=]path. The key defaults to the file name. May be repeated."`
+
+	Store         string            `required:"" help:"Name of the SharedSecretStore of the group the secret is read from."`
+	ControlPlanes []string          `name:"controlplane" help:"Name of a control plane in the group to share the secret with. May be repeated."`
+	Selector      map[string]string `help:"Labels of the control planes in the group to share the secret with."`
+	Namespace     string            `default:"default" help:"Namespace inside the control planes the secret is created in."`
+}
+
+func (c *createCmd) Help() string {
+	return `
+The create command stores the supplied values in a secret in the namespace of
+the group, and creates a SharedExternalSecret that projects it into the
+selected control planes of the group. The SharedSecretStore given with --store
+must be able to read secrets from the namespace of the group.
+
+If neither --controlplane nor --selector are supplied, the secret is shared
+with all control planes in the group.`
+}
+
+// Run executes the create command.
+func (c *createCmd) Run(p pterm.TextPrinter, cmd *Cmd, kClient kubernetes.Interface, dClient dynamic.Interface) error {
+	data, err := parseData(c.FromLiteral, c.FromFile, os.ReadFile)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: cmd.Group,
+			Labels:    map[string]string{managedByLabel: managedByValue},
+		},
+		Data: data,
+	}
+	if err := kube.NewSecretApplicator(kClient).Apply(ctx, cmd.Group, secret); err != nil {
+		return errors.Wrap(err, errCreateSecret)
+	}
+
+	ses := &resources.SharedExternalSecret{}
+	ses.SetGroupVersionKind(resources.SharedExternalSecretGVK)
+	ses.SetName(c.Name)
+	ses.SetNamespace(cmd.Group)
+	ses.SetLabels(map[string]string{managedByLabel: managedByValue})
+	var sel *metav1.LabelSelector
+	if len(c.Selector) > 0 {
+		sel = &metav1.LabelSelector{MatchLabels: c.Selector}
+	}
+	if len(c.ControlPlanes) == 0 && sel == nil {
+		sel = &metav1.LabelSelector{}
+	}
+	ses.SetControlPlaneSelector(c.ControlPlanes, sel)
+	remote := make([]any, 0, len(data))
+	for _, k := range sortedKeys(data) {
+		remote = append(remote, map[string]any{
+			"secretKey": k,
+			"remoteRef": map[string]any{"key": c.Name, "property": k},
+		})
+	}
+	_ = unstructured.SetNestedField(ses.Object, c.Name, "spec", "externalSecretName")
+	_ = unstructured.SetNestedField(ses.Object, []any{c.Namespace}, "spec", "namespaceSelector", "names")
+	_ = unstructured.SetNestedField(ses.Object, map[string]any{
+		"secretStoreRef": map[string]any{"kind": "ClusterSecretStore", "name": c.Store},
+		"target":         map[string]any{"name": c.Name},
+		"data":           remote,
+	}, "spec", "externalSecretSpec")
+	if _, err := dClient.Resource(resources.SharedExternalSecretGVR).Namespace(cmd.Group).Create(ctx, ses.GetUnstructured(), metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, errCreateSharedSecr)
+	}
+	p.Printfln("%s/%s created", cmd.Group, c.Name)
+	return nil
+}
+
+// parseData builds the data of a secret from key=value literals and
+// [key=]path files.
+func parseData(literals, files []string, read func(string) ([]byte, error)) (map[string][]byte, error) {
+	if len(literals) == 0 && len(files) == 0 {
+		return nil, errors.New(errNoData)
+	}
+	data := map[string][]byte{}
+	add := func(k string, v []byte) error {
+		if _, ok := data[k]; ok {
+			return errors.Errorf(errFmtDuplicateKey, k)
+		}
+		data[k] = v
+		return nil
+	}
+	for _, l := range literals {
+		k, v, ok := strings.Cut(l, "=")
+		if !ok || k == "" {
+			return nil, errors.Errorf(errFmtInvalidPair, l)
+		}
+		if err := add(k, []byte(v)); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range files {
+		k, path, ok := strings.Cut(f, "=")
+		if !ok {
+			k, path = filepath.Base(f), f
+		}
+		if k == "" {
+			return nil, errors.Errorf(errFmtInvalidPair, f)
+		}
+		b, err := read(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtReadFile, path)
+		}
+		if err := add(k, b); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
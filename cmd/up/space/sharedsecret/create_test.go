@@ -0,0 +1,98 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedsecret
+
+import (
+	"os"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseData(t *testing.T) {
+	files := map[string][]byte{
+		"/tmp/cert.pem": []byte("cert"),
+		"/tmp/key.pem":  []byte("key"),
+	}
+	read := func(path string) ([]byte, error) {
+		if b, ok := files[path]; ok {
+			return b, nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	type args struct {
+		literals []string
+		files    []string
+	}
+	type want struct {
+		data map[string][]byte
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoData": {
+			reason: "At least one literal or file must be supplied.",
+			want:   want{err: errors.New(errNoData)},
+		},
+		"LiteralsAndFiles": {
+			reason: "Files should be keyed by their base name unless a key is given.",
+			args: args{
+				literals: []string{"user=admin", "password=a=b"},
+				files:    []string{"/tmp/cert.pem", "tls.key=/tmp/key.pem"},
+			},
+			want: want{data: map[string][]byte{
+				"user":     []byte("admin"),
+				"password": []byte("a=b"),
+				"cert.pem": []byte("cert"),
+				"tls.key":  []byte("key"),
+			}},
+		},
+		"InvalidLiteral": {
+			reason: "A literal without a key should be rejected.",
+			args:   args{literals: []string{"=admin"}},
+			want:   want{err: errors.Errorf(errFmtInvalidPair, "=admin")},
+		},
+		"DuplicateKey": {
+			reason: "A key supplied more than once should be rejected.",
+			args: args{
+				literals: []string{"cert.pem=x"},
+				files:    []string{"/tmp/cert.pem"},
+			},
+			want: want{err: errors.Errorf(errFmtDuplicateKey, "cert.pem")},
+		},
+		"MissingFile": {
+			reason: "A file that cannot be read should be reported.",
+			args:   args{files: []string{"/tmp/nope"}},
+			want:   want{err: errors.Wrapf(os.ErrNotExist, errFmtReadFile, "/tmp/nope")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseData(tc.args.literals, tc.args.files, read)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nparseData(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("\n%s\nparseData(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedsecret
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/resources"
+)
+
+const (
+	errDeleteSharedSecret = "failed to delete shared secret"
+	errDeleteSecret       = "failed to delete secret"
+)
+
+// deleteCmd deletes a shared secret.
+type deleteCmd struct {
+	Name string `arg:"" required:"" help:"Name of the shared secret."`
+}
+
+// Run executes the delete command.
+func (c *deleteCmd) Run(p pterm.TextPrinter, cmd *Cmd, kClient kubernetes.Interface, dClient dynamic.Interface) error {
+	ctx := context.Background()
+	if err := dClient.Resource(resources.SharedExternalSecretGVR).Namespace(cmd.Group).Delete(ctx, c.Name, metav1.DeleteOptions{}); err != nil {
+		return errors.Wrap(err, errDeleteSharedSecret)
+	}
+	// Only delete the secret holding the values if it was created by the
+	// create command.
+	s, err := kClient.CoreV1().Secrets(cmd.Group).Get(ctx, c.Name, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, errDeleteSecret)
+	}
+	if err == nil && s.GetLabels()[managedByLabel] == managedByValue {
+		if err := kClient.CoreV1().Secrets(cmd.Group).Delete(ctx, c.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrap(err, errDeleteSecret)
+		}
+	}
+	p.Printfln("%s/%s deleted", cmd.Group, c.Name)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedsecret
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/resources"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const errListSharedSecrets = "failed to list shared secrets"
+
+// listCmd lists the shared secrets of a group.
+type listCmd struct{}
+
+var fieldNames = []string{"NAME", "SECRET", "STORE", "READY"}
+
+// Run executes the list command.
+func (c *listCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter, cmd *Cmd, dClient dynamic.Interface) error {
+	l, err := dClient.Resource(resources.SharedExternalSecretGVR).Namespace(cmd.Group).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, errListSharedSecrets)
+	}
+	if len(l.Items) == 0 {
+		p.Printfln("No shared secrets found in group %s", cmd.Group)
+		return nil
+	}
+	secrets := make([]resources.SharedExternalSecret, len(l.Items))
+	for i := range l.Items {
+		secrets[i] = resources.SharedExternalSecret{Unstructured: l.Items[i]}
+	}
+	return printer.Print(secrets, fieldNames, extractFields)
+}
+
+func extractFields(obj any) []string {
+	s := obj.(resources.SharedExternalSecret)
+	return []string{s.GetName(), s.GetExternalSecretName(), s.GetSecretStoreName(), string(s.GetCondition(xpv1.TypeReady).Status)}
+}
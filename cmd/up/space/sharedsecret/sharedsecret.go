@@ -0,0 +1,61 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedsecret
+
+import (
+	"github.com/alecthomas/kong"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+)
+
+const (
+	// managedByLabel marks the secrets holding the values of shared secrets
+	// created with the CLI, so that they are deleted alongside them.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "up"
+)
+
+// AfterApply constructs and binds the Kubernetes clients of the Space to any
+// subcommands that have Run() methods that receive them.
+func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
+	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	kClient, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	kongCtx.BindTo(kClient, (*kubernetes.Interface)(nil))
+	kongCtx.BindTo(dClient, (*dynamic.Interface)(nil))
+	return nil
+}
+
+// Cmd contains commands for managing secrets shared with the control planes
+// of a group.
+type Cmd struct {
+	Create createCmd `cmd:"" help:"Share a secret with control planes in a group."`
+	List   listCmd   `cmd:"" help:"List the shared secrets of a group."`
+	Delete deleteCmd `cmd:"" help:"Delete a shared secret."`
+
+	Group      string `short:"g" default:"default" help:"Control plane group the shared secret belongs to."`
+	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
+}
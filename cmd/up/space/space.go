@@ -20,6 +20,10 @@ import (
 	"github.com/alecthomas/kong"
 
 	"github.com/upbound/up/cmd/up/space/billing"
+	"github.com/upbound/up/cmd/up/space/cert"
+	"github.com/upbound/up/cmd/up/space/observability"
+	"github.com/upbound/up/cmd/up/space/rbac"
+	"github.com/upbound/up/cmd/up/space/sharedsecret"
 	"github.com/upbound/up/internal/feature"
 )
 
@@ -40,6 +44,13 @@ type Cmd struct {
 	Destroy destroyCmd  `cmd:"" help:"Remove the Upbound Spaces deployment."`
 	Upgrade upgradeCmd  `cmd:"" help:"Upgrade the Upbound Spaces deployment."`
 	Billing billing.Cmd `cmd:""`
+	Cert    cert.Cmd    `cmd:"" help:"Manage the certificates of a Space."`
+	Export  exportCmd   `cmd:"" help:"Export the Space-level configuration of a Space."`
+	Import  importCmd   `cmd:"" help:"Import the Space-level configuration of a Space."`
+
+	SharedSecret  sharedsecret.Cmd  `cmd:"" name:"sharedsecret" help:"Manage secrets shared with the control planes of a group."`
+	Observability observability.Cmd `cmd:"" help:"Manage the export of telemetry from control planes."`
+	RBAC          rbac.Cmd          `cmd:"" name:"rbac" help:"Inspect access to the objects of a Space."`
 }
 
 type commonParams struct {
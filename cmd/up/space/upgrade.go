@@ -16,12 +16,17 @@ package space
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/alecthomas/kong"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/pterm/pterm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
 
@@ -30,6 +35,7 @@ import (
 	"github.com/upbound/up/internal/install"
 	"github.com/upbound/up/internal/install/helm"
 	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/space/compat"
 	"github.com/upbound/up/internal/upterm"
 )
 
@@ -37,6 +43,12 @@ const (
 	errParseUpgradeParameters = "unable to parse upgrade parameters"
 )
 
+var controlPlaneGVR = schema.GroupVersionResource{
+	Group:    "spaces.upbound.io",
+	Version:  "v1beta1",
+	Resource: "controlplanes",
+}
+
 // BeforeApply sets default values in login before assignment and validation.
 func (c *upgradeCmd) BeforeApply() error {
 	c.prompter = input.NewPrompter()
@@ -83,6 +95,11 @@ func (c *upgradeCmd) AfterApply(insCtx *install.Context, kongCtx *kong.Context,
 		return err
 	}
 	c.kClient = kClient
+	dClient, err := dynamic.NewForConfig(insCtx.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
 	secret := kube.NewSecretApplicator(kClient)
 	c.pullSecret = kube.NewImagePullApplicator(secret)
 	ins, err := helm.NewManager(insCtx.Kubeconfig,
@@ -126,6 +143,7 @@ type upgradeCmd struct {
 	id         string
 	token      string
 	kClient    kubernetes.Interface
+	dClient    dynamic.Interface
 	quiet      config.QuietFlag
 
 	// NOTE(hasheddan): version is currently required for upgrade with OCI image
@@ -133,6 +151,7 @@ type upgradeCmd struct {
 	Version string `arg:"" help:"Upbound Spaces version to upgrade to."`
 
 	Rollback bool `help:"Rollback to previously installed version on failed upgrade."`
+	Yes      bool `name:"yes" type:"bool" help:"Answer yes to all questions"`
 
 	commonParams
 	install.CommonParams
@@ -149,6 +168,17 @@ func (c *upgradeCmd) Run(insCtx *install.Context) error {
 	}
 	overrideRegistry(c.Registry.String(), params)
 
+	if c.warnIncompatibleControlPlanes(ctx) && !c.Yes {
+		pterm.DefaultInteractiveConfirm.DefaultText = "Would you like to upgrade anyway?"
+		pterm.Println() // Blank line
+		result, _ := pterm.DefaultInteractiveConfirm.Show()
+		pterm.Println() // Blank line
+		if !result {
+			pterm.Error.Println("control planes must be upgraded before upgrading the Space")
+			return nil
+		}
+	}
+
 	// Create or update image pull secret.
 	if err := c.pullSecret.Apply(ctx, defaultImagePullSecret, ns, c.id, c.token, c.RegistryEndpoint.String()); err != nil {
 		return errors.Wrap(err, errCreateImagePullSecret)
@@ -179,3 +209,36 @@ func (c *upgradeCmd) upgradeUpbound(params map[string]any) error {
 
 	return nil
 }
+
+// warnIncompatibleControlPlanes warns about control planes that run a
+// Crossplane version the requested Spaces version does not support. It returns
+// true if there are any.
+func (c *upgradeCmd) warnIncompatibleControlPlanes(ctx context.Context) bool {
+	r, known, err := compat.CrossplaneRange(c.Version)
+	if err != nil || !known {
+		return false
+	}
+	l, err := c.dClient.Resource(controlPlaneGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		pterm.Warning.Printfln("Unable to check the Crossplane version of control planes: %s", err)
+		return false
+	}
+	affected := []string{}
+	for _, u := range l.Items {
+		v, _ := fieldpath.Pave(u.Object).GetString("spec.crossplane.version")
+		if v == "" {
+			continue
+		}
+		if ok, err := r.Contains(v); err == nil && !ok {
+			affected = append(affected, fmt.Sprintf("%s/%s (Crossplane %s)", u.GetNamespace(), u.GetName(), v))
+		}
+	}
+	if len(affected) == 0 {
+		return false
+	}
+	pterm.Warning.Printfln("Spaces %s supports Crossplane %s in hosted control planes. The following control planes must be upgraded:", c.Version, r)
+	for _, a := range affected {
+		pterm.Println(fmt.Sprintf("  %s", a))
+	}
+	return true
+}
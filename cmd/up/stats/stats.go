@@ -0,0 +1,181 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/controlplane/stats"
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errTokenRequired = "--token is required with --controlplane"
+)
+
+var (
+	kindFieldNames        = []string{"GROUP", "KIND", "CATEGORY", "COUNT"}
+	providerFieldNames    = []string{"PROVIDER", "KINDS", "COUNT"}
+	claimFieldNames       = []string{"NAMESPACE", "CLAIMS"}
+	compositionFieldNames = []string{"COMPOSITION", "COMPOSITES"}
+)
+
+// BeforeReset is the first hook to run.
+func (c *Cmd) BeforeReset(p *kong.Path, maturity feature.Maturity) error {
+	return feature.HideMaturity(p, maturity)
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *Cmd) AfterApply() error {
+	var cfg *rest.Config
+	if c.ControlPlane != "" {
+		if c.Token == "" {
+			return errors.New(errTokenRequired)
+		}
+		upCtx, err := upbound.NewFromFlags(c.Flags)
+		if err != nil {
+			return err
+		}
+		cfg, err = kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.ControlPlane), c.Token, upCtx.WrapTransport)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		cfg, err = kube.GetKubeConfig(c.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+	// NOTE: listing every custom resource of a large control plane issues
+	// many requests, so we rate limit them client-side to protect the API
+	// server.
+	cfg.QPS = c.QPS
+	cfg.Burst = int(c.QPS * 2)
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	c.dClient = dClient
+	return nil
+}
+
+// Cmd summarizes the objects of a control plane.
+type Cmd struct {
+	dClient dynamic.Interface
+
+	ControlPlane string `name:"controlplane" help:"Name of the Upbound control plane to summarize. Defaults to the control plane of the current kubeconfig context." predictor:"ctps"`
+	Token        string `help:"API token used to authenticate. Required with --controlplane."`
+	Kubeconfig   string `type:"existingfile" help:"Override default kubeconfig path."`
+
+	PageSize int64   `default:"500" help:"Number of objects to list per request."`
+	QPS      float32 `name:"qps" default:"10" help:"Maximum number of requests per second sent to the control plane."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+func (c *Cmd) Help() string {
+	return `
+The stats command summarizes the custom resources of a control plane, e.g. to
+size a target control plane before a migration. It reports:
+
+- the number of objects of each custom resource kind
+- the number of managed resources of each provider
+- the number of claims in each namespace
+- the number of composite resources using each Composition
+
+Objects are listed in pages of --page-size objects, and requests are limited
+to --qps per second to avoid overloading the control plane. Use --format=json
+or --format=yaml for machine-readable output.`
+}
+
+// Run executes the stats command.
+func (c *Cmd) Run(printer upterm.ObjectPrinter) error {
+	col := stats.NewCollector(c.dClient, stats.WithPageSize(c.PageSize))
+	var s *stats.Stats
+	collect := func() error {
+		var err error
+		s, err = col.Collect(context.Background())
+		return err
+	}
+	if err := upterm.WrapWithSuccessSpinner("Collecting stats", upterm.CheckmarkSuccessSpinner, collect); err != nil {
+		return err
+	}
+
+	if printer.Format == config.JSON || printer.Format == config.YAML {
+		return printer.Print(s, nil, nil)
+	}
+	tables := []struct {
+		heading       string
+		obj           any
+		fieldNames    []string
+		extractFields func(any) []string
+	}{
+		{"Kinds", s.Kinds, kindFieldNames, extractKindFields},
+		{"Managed resources per provider", s.Providers, providerFieldNames, extractProviderFields},
+		{"Claims per namespace", s.Claims, claimFieldNames, extractClaimFields},
+		{"Composite resources per Composition", s.Compositions, compositionFieldNames, extractCompositionFields},
+	}
+	for _, t := range tables {
+		if printer.Quiet {
+			break
+		}
+		pterm.DefaultSection.Println(t.heading)
+		if err := printer.Print(t.obj, t.fieldNames, t.extractFields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractKindFields(obj any) []string {
+	k := obj.(stats.KindCount)
+	return []string{k.Group, k.Kind, k.Category, strconv.Itoa(k.Count)}
+}
+
+func extractProviderFields(obj any) []string {
+	p := obj.(stats.ProviderCount)
+	name := p.Provider
+	if name == "" {
+		name = "<unknown>"
+	}
+	return []string{name, strconv.Itoa(p.Kinds), strconv.Itoa(p.Count)}
+}
+
+func extractClaimFields(obj any) []string {
+	n := obj.(stats.NamespaceCount)
+	return []string{n.Namespace, strconv.Itoa(n.Count)}
+}
+
+func extractCompositionFields(obj any) []string {
+	n := obj.(stats.NameCount)
+	return []string{n.Name, strconv.Itoa(n.Count)}
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundle
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/supportbundle"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	errTokenRequired = "--token is required with --controlplane"
+	errCreateBundle  = "failed to create support bundle file"
+)
+
+// BeforeReset is the first hook to run.
+func (c *Cmd) BeforeReset(p *kong.Path, maturity feature.Maturity) error {
+	return feature.HideMaturity(p, maturity)
+}
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *Cmd) AfterApply() error {
+	var cfg *rest.Config
+	if c.ControlPlane != "" {
+		if c.Token == "" {
+			return errors.New(errTokenRequired)
+		}
+		upCtx, err := upbound.NewFromFlags(c.Flags)
+		if err != nil {
+			return err
+		}
+		cfg, err = kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.ControlPlane), c.Token, upCtx.WrapTransport)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		cfg, err = kube.GetKubeConfig(c.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	c.kClient = kClient
+	c.dClient = dClient
+	return nil
+}
+
+// Cmd collects a support bundle of a control plane.
+type Cmd struct {
+	kClient kubernetes.Interface
+	dClient dynamic.Interface
+
+	ControlPlane string `name:"controlplane" help:"Name of the Upbound control plane to collect a support bundle of. Defaults to the control plane of the current kubeconfig context." predictor:"ctps"`
+	Token        string `help:"API token used to authenticate. Required with --controlplane."`
+	Kubeconfig   string `type:"existingfile" help:"Override default kubeconfig path."`
+
+	Output   string `short:"o" type:"path" default:"up-support-bundle.tar.gz" help:"Path of the support bundle to write."`
+	LogLines int64  `default:"1000" help:"Number of most recent log lines to collect per container."`
+	Events   int    `default:"500" help:"Number of most recent events to collect."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+func (c *Cmd) Help() string {
+	return `
+The support-bundle command gathers diagnostic information about a control plane
+into a gzipped tar archive that can be attached to support tickets:
+
+- the Kubernetes version and the images of the Crossplane deployments
+- installed packages and their conditions
+- the most recent events
+- the most recent logs of Crossplane and provider pods
+- the number of claims, composite and managed resources of each kind, and how
+  many of them are ready
+
+Secrets are never collected. Logs, events and package conditions are redacted
+of common credential patterns and of the external names of managed resources.
+Review the bundle before sharing it.`
+}
+
+// Run executes the support-bundle command.
+func (c *Cmd) Run(p pterm.TextPrinter) error {
+	f, err := os.OpenFile(c.Output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, errCreateBundle)
+	}
+	defer f.Close() //nolint:errcheck
+
+	col := supportbundle.NewCollector(c.kClient, c.dClient,
+		supportbundle.WithLogLines(c.LogLines),
+		supportbundle.WithEventLimit(c.Events),
+	)
+	var res *supportbundle.Result
+	collect := func() error {
+		var err error
+		res, err = col.Collect(context.Background(), f)
+		return err
+	}
+	if err := upterm.WrapWithSuccessSpinner("Collecting support bundle", upterm.CheckmarkSuccessSpinner, collect); err != nil {
+		return err
+	}
+	for _, w := range res.Warnings {
+		pterm.Warning.Println(w)
+	}
+	p.Printfln("Support bundle with %d files written to %s", len(res.Files), c.Output)
+	return nil
+}
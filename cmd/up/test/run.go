@@ -0,0 +1,146 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/upbound/up/internal/comptest"
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	suiteName = "up test"
+
+	errWriteJUnit  = "unable to write JUnit report"
+	errFmtFailures = "%d of %d tests failed"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *runCmd) AfterApply(upCtx *upbound.Context) error {
+	cases, err := comptest.Load(c.Paths...)
+	if err != nil {
+		return err
+	}
+	c.cases = cases
+
+	cfg, err := kube.GetControlPlaneKubeConfig(upCtx.ProxyEndpoint, path.Join(upCtx.Account, c.ControlPlane), c.Token, upCtx.WrapTransport)
+	if err != nil {
+		return err
+	}
+	dClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	mapper, err := kube.NewDiscoveryRESTMapper(cfg)
+	if err != nil {
+		return err
+	}
+	c.runner = comptest.NewRunner(dClient, mapper, comptest.WithKeep(c.Keep))
+	return nil
+}
+
+// runCmd runs Composition tests against a control plane.
+type runCmd struct {
+	cases  []comptest.Case
+	runner *comptest.Runner
+
+	Paths        []string `arg:"" type:"path" help:"Test case files or directories of test case files."`
+	ControlPlane string   `name:"controlplane" required:"" help:"Name of the control plane to run the tests against." predictor:"ctps"`
+	Token        string   `required:"" help:"API token used to authenticate."`
+	JUnit        string   `name:"junit" type:"path" help:"Write a JUnit XML report to the given file."`
+	Keep         bool     `help:"Keep the claims created by the tests instead of deleting them."`
+}
+
+func (c *runCmd) Help() string {
+	return `
+The run command runs declarative Composition tests against a control plane.
+Each test case creates a claim, or a composite resource, and waits for it to
+report the expected conditions and to compose the expected resources:
+
+  name: creates-bucket
+  claim:
+    apiVersion: example.org/v1alpha1
+    kind: Bucket
+    metadata:
+      name: test-bucket
+  timeout: 5m
+  expect:
+    conditions: [Ready, Synced]
+    resources:
+    - apiVersion: s3.aws.upbound.io/v1beta1
+      kind: Bucket
+      count: 1
+
+Conditions default to Ready and Synced. Resources without a count must be
+composed at least once. Directories are read recursively for .yaml and .yml
+files, each of which may contain multiple test cases separated by ---.
+
+Each run creates a new claim, named after the claim of the test case with a
+random suffix, so existing objects are never changed. The claims are deleted
+once their test has finished unless --keep is supplied.`
+}
+
+// Run executes the run command.
+func (c *runCmd) Run(p pterm.TextPrinter) error {
+	ctx := context.Background()
+	results := make([]comptest.Result, len(c.cases))
+	failed := 0
+	for i, tc := range c.cases {
+		p.Printfln("RUN   %s", tc.Name)
+		results[i] = c.runner.Run(ctx, tc)
+		if results[i].Passed() {
+			p.Printfln("PASS  %s (%s)", tc.Name, results[i].Duration.Round(time.Millisecond))
+		} else {
+			failed++
+			p.Printfln("FAIL  %s (%s): %s", tc.Name, results[i].Duration.Round(time.Millisecond), results[i].Failure)
+		}
+		if c.Keep && results[i].Claim != "" {
+			p.Printfln("      kept claim %s", results[i].Claim)
+		}
+	}
+
+	if c.JUnit != "" {
+		if err := writeJUnit(c.JUnit, results); err != nil {
+			return errors.Wrap(err, errWriteJUnit)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf(errFmtFailures, failed, len(results))
+	}
+	p.Printfln("%d tests passed", len(results))
+	return nil
+}
+
+func writeJUnit(file string, results []comptest.Result) error {
+	f, err := os.Create(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+	if err := comptest.WriteJUnit(f, suiteName, results); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
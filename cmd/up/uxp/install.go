@@ -42,11 +42,11 @@ func (c *installCmd) AfterApply(insCtx *install.Context) error {
 		repo = uxpUnstableRepoURL
 	}
 	mgr, err := helm.NewManager(insCtx.Kubeconfig,
-		chartName,
+		ChartName,
 		repo,
 		helm.WithNamespace(insCtx.Namespace),
 		helm.WithChart(c.Bundle),
-		helm.WithAlternateChart(alternateChartName))
+		helm.WithAlternateChart(AlternateChartName))
 	if err != nil {
 		return err
 	}
@@ -28,7 +28,7 @@ func (c *uninstallCmd) AfterApply(insCtx *install.Context) error {
 	// NOTE(hasheddan): we always pass default repo URL because the repo URL is
 	// not considered during uninstall.
 	mgr, err := helm.NewManager(insCtx.Kubeconfig,
-		chartName,
+		ChartName,
 		&url.URL{},
 		helm.WithNamespace(insCtx.Namespace))
 	if err != nil {
@@ -36,11 +36,11 @@ func (c *upgradeCmd) AfterApply(insCtx *install.Context) error {
 		repo = uxpUnstableRepoURL
 	}
 	ins, err := helm.NewManager(insCtx.Kubeconfig,
-		chartName,
+		ChartName,
 		repo,
 		helm.WithNamespace(insCtx.Namespace),
 		helm.WithChart(c.Bundle),
-		helm.WithAlternateChart(alternateChartName),
+		helm.WithAlternateChart(AlternateChartName),
 		helm.RollbackOnError(c.Rollback),
 		helm.Force(c.Force))
 	if err != nil {
@@ -25,8 +25,11 @@ import (
 )
 
 const (
-	chartName          = "universal-crossplane"
-	alternateChartName = "crossplane"
+	// ChartName is the name of the UXP Helm chart.
+	ChartName = "universal-crossplane"
+	// AlternateChartName is the name of the Crossplane Helm chart, which UXP
+	// can be installed over.
+	AlternateChartName = "crossplane"
 )
 
 var (
@@ -36,7 +39,15 @@ var (
 
 // AfterApply constructs and binds Upbound-specific context to any subcommands
 // that have Run() methods that receive it.
-func (c *Cmd) AfterApply(kongCtx *kong.Context, upCtx *upbound.Context) error {
+func (c *Cmd) AfterApply(kongCtx *kong.Context) error {
+	// Listing versions does not require access to a cluster.
+	if kongCtx.Selected().Name == "versions" {
+		return nil
+	}
+	upCtx, err := upbound.NewFromFlags(c.Flags, upbound.AllowMissingProfile())
+	if err != nil {
+		return err
+	}
 	kubeconfig, err := kube.GetKubeConfig(c.Kubeconfig)
 	if err != nil {
 		return err
@@ -56,7 +67,11 @@ type Cmd struct {
 	Install   installCmd   `cmd:"" help:"Install UXP."`
 	Uninstall uninstallCmd `cmd:"" help:"Uninstall UXP."`
 	Upgrade   upgradeCmd   `cmd:"" help:"Upgrade UXP."`
+	Versions  versionsCmd  `cmd:"" help:"List available UXP versions."`
 
 	Kubeconfig string `type:"existingfile" help:"Override default kubeconfig path."`
 	Namespace  string `short:"n" env:"UXP_NAMESPACE" default:"upbound-system" help:"Kubernetes namespace for UXP."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
 }
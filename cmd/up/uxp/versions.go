@@ -0,0 +1,123 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uxp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+
+	"github.com/upbound/up/internal/space/compat"
+	"github.com/upbound/up/internal/upterm"
+)
+
+const (
+	indexTimeout = 30 * time.Second
+
+	errFetchIndex       = "unable to fetch UXP chart index"
+	errFmtUnknownSpaces = "compatibility of Spaces version %s is unknown"
+)
+
+// versionsCmd lists the available UXP versions.
+type versionsCmd struct {
+	CompatibleWithSpace string `placeholder:"VERSION" help:"Only list UXP versions supported by the hosted control planes of the given Spaces version."`
+	Unstable            bool   `help:"List unstable versions from the main channel."`
+}
+
+func (c *versionsCmd) Help() string {
+	return `
+The versions command lists the UXP versions available for installation, newest
+first. With --compatible-with-space, only the versions supported by the hosted
+control planes of the given Upbound Spaces version are listed.`
+}
+
+var versionFieldNames = []string{"VERSION", "CREATED"}
+
+// Run executes the versions command.
+func (c *versionsCmd) Run(printer upterm.ObjectPrinter) error {
+	var r compat.Range
+	if c.CompatibleWithSpace != "" {
+		var known bool
+		var err error
+		r, known, err = compat.CrossplaneRange(c.CompatibleWithSpace)
+		if err != nil {
+			return err
+		}
+		if !known {
+			return errors.Errorf(errFmtUnknownSpaces, c.CompatibleWithSpace)
+		}
+	}
+
+	repoURL := RepoURL
+	if c.Unstable {
+		repoURL = uxpUnstableRepoURL
+	}
+	idx, err := fetchIndex(repoURL.JoinPath("index.yaml").String())
+	if err != nil {
+		return errors.Wrap(err, errFetchIndex)
+	}
+
+	versions := repo.ChartVersions{}
+	for _, v := range idx.Entries[ChartName] {
+		if _, err := semver.NewVersion(v.Version); err != nil {
+			continue
+		}
+		if c.CompatibleWithSpace != "" {
+			if ok, _ := r.Contains(v.Version); !ok {
+				continue
+			}
+		}
+		versions = append(versions, v)
+	}
+	return printer.Print(versions, versionFieldNames, extractVersionFields)
+}
+
+func fetchIndex(url string) (*repo.IndexFile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() //nolint:errcheck
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New(http.StatusText(res.StatusCode))
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	idx := &repo.IndexFile{}
+	if err := yaml.Unmarshal(b, idx); err != nil {
+		return nil, err
+	}
+	idx.SortEntries()
+	return idx, nil
+}
+
+func extractVersionFields(obj any) []string {
+	v := obj.(*repo.ChartVersion)
+	return []string{v.Version, v.Created.Format(time.RFC3339)}
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/pterm/pterm"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	xpextv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+
+	"github.com/upbound/up/internal/composition"
+	"github.com/upbound/up/internal/credhelper"
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+	"github.com/upbound/up/internal/xpkg/dep"
+	"github.com/upbound/up/internal/xpkg/dep/cache"
+	"github.com/upbound/up/internal/xpkg/dep/manager"
+	"github.com/upbound/up/internal/xpkg/dep/resolver/image"
+)
+
+const (
+	errOpenFile          = "unable to open composition file"
+	errDecodeComposition = "unable to decode composition"
+	errNoCompositions    = "no compositions found in file"
+	errFmtResolve        = "unable to resolve package %s"
+	errInvalid           = "composition is not valid"
+)
+
+// AfterApply sets default values in command after assignment and validation.
+func (c *Cmd) AfterApply(offline feature.Offline) error {
+	upCtx, err := upbound.NewFromFlags(upbound.Flags{Domain: c.Domain, Profile: c.Profile}, upbound.AllowMissingProfile())
+	if err != nil {
+		return err
+	}
+	ch, err := cache.NewLocal(c.CacheDir)
+	if err != nil {
+		return err
+	}
+	m, err := manager.New(
+		manager.WithCache(ch),
+		manager.WithResolver(image.NewResolver(
+			image.WithFetcher(image.NewLocalFetcher(
+				image.WithKeychain(credhelper.NewKeychain(
+					credhelper.WithDomain(upCtx.Domain.Hostname()),
+					credhelper.WithProfile(upCtx.ProfileName),
+				)),
+			)),
+		)),
+		manager.WithOffline(bool(offline)),
+	)
+	if err != nil {
+		return err
+	}
+	c.m = m
+	return nil
+}
+
+// Cmd validates compositions against the schemas of provider packages.
+type Cmd struct {
+	m *manager.Manager
+
+	File     string   `short:"f" required:"" type:"existingfile" help:"Path to a file containing one or more compositions."`
+	Against  []string `required:"" help:"Packages providing the schemas of the composed resources, e.g. xpkg.upbound.io/upbound/provider-aws:v0.40.0. May be repeated."`
+	CacheDir string   `short:"d" help:"Directory used for caching package images." default:"~/.up/cache/" env:"CACHE_DIR" type:"path"`
+
+	// The Upbound flags used to pull packages. Flags are not embedded as
+	// the short flag of --debug conflicts with that of --cache-dir.
+	Domain  *url.URL `env:"UP_DOMAIN" default:"https://upbound.io" help:"Root Upbound domain."`
+	Profile string   `env:"UP_PROFILE" help:"Profile used to pull packages." predictor:"profiles"`
+}
+
+func (c *Cmd) Help() string {
+	return `
+The validate command checks compositions before they are deployed to a control
+plane. The packages given with --against, and their dependencies, are
+downloaded into the package cache, after which validation runs offline.
+
+The base of each composed resource is checked for fields that are not part of
+the schema of its kind, and the field paths patched to and from each composed
+resource are checked to exist in that schema.`
+}
+
+var fieldNames = []string{"COMPOSITION", "RESOURCE", "PATH", "MESSAGE"}
+
+// result is an issue found in a named composition.
+type result struct {
+	Composition string `json:"composition"`
+	composition.Issue
+}
+
+// Run executes the validate command.
+func (c *Cmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	comps, err := c.readCompositions()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	crds := []*extv1.CustomResourceDefinition{}
+	for _, a := range c.Against {
+		_, pkgs, err := c.m.AddAll(ctx, parseAgainst(a))
+		if err != nil {
+			return errors.Wrapf(err, errFmtResolve, a)
+		}
+		for _, pkg := range pkgs {
+			for _, o := range pkg.Objects() {
+				if crd, ok := o.(*extv1.CustomResourceDefinition); ok {
+					crds = append(crds, crd)
+				}
+			}
+		}
+	}
+
+	v := composition.NewValidator(crds...)
+	results := []result{}
+	for _, comp := range comps {
+		for _, i := range v.Validate(comp) {
+			results = append(results, result{Composition: comp.GetName(), Issue: i})
+		}
+	}
+	if len(results) == 0 {
+		p.Printfln("%d composition(s) are valid.", len(comps))
+		return nil
+	}
+	if err := printer.Print(results, fieldNames, extractFields); err != nil {
+		return err
+	}
+	return errors.New(errInvalid)
+}
+
+func (c *Cmd) readCompositions() ([]*xpextv1.Composition, error) {
+	f, err := os.Open(c.File)
+	if err != nil {
+		return nil, errors.Wrap(err, errOpenFile)
+	}
+	defer f.Close() //nolint:errcheck
+
+	comps := []*xpextv1.Composition{}
+	d := kyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		comp := &xpextv1.Composition{}
+		if err := d.Decode(comp); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrap(err, errDecodeComposition)
+		}
+		if comp.GroupVersionKind() != xpextv1.CompositionGroupVersionKind {
+			continue
+		}
+		comps = append(comps, comp)
+	}
+	if len(comps) == 0 {
+		return nil, errors.New(errNoCompositions)
+	}
+	return comps, nil
+}
+
+// parseAgainst parses a package reference of the form source:version or
+// source@version into a dependency.
+func parseAgainst(ref string) v1beta1.Dependency {
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i] + "@" + ref[i+1:]
+	}
+	return dep.New(ref)
+}
+
+func extractFields(obj any) []string {
+	r := obj.(result)
+	return []string{r.Composition, r.Resource, r.Path, r.Message}
+}
@@ -311,8 +311,9 @@ func (c *batchCmd) pushWithRetry(p pterm.TextPrinter, upCtx *upbound.Context, im
 	retryMsg := ""
 	for i := uint(0); i < tries; i++ {
 		p.Printfln("Pushing xpkg to %s.%s", t, retryMsg)
-		err := PushImages(p, upCtx, imgs, t, c.Create, c.Flags.Profile)
+		err := PushImages(context.Background(), upCtx, imgs, t, c.Create, c.Flags.Profile, nil)
 		if err == nil {
+			p.Printfln("xpkg pushed to %s", t)
 			break
 		}
 		if i == tries-1 { // no more retries
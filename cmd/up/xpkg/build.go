@@ -17,7 +17,10 @@ package xpkg
 import (
 	"context"
 	"io"
+	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/parser"
@@ -37,6 +40,9 @@ const (
 	errBuildPackage    = "failed to build package"
 	errImageDigest     = "failed to get package digest"
 	errCreatePackage   = "failed to create package file"
+	errSourceDateEpoch = "failed to parse SOURCE_DATE_EPOCH"
+
+	envSourceDateEpoch = "SOURCE_DATE_EPOCH"
 )
 
 // AfterApply constructs and binds Upbound-specific context to any subcommands
@@ -113,6 +119,7 @@ type buildCmd struct {
 	ExamplesRoot string   `short:"e" help:"Path to package examples directory." default:"./examples"`
 	AuthExt      string   `short:"a" help:"Path to an authentication extension file." default:"auth.yaml"`
 	Ignore       []string `help:"Paths, specified relative to --package-root, to exclude from the package."`
+	Reproducible bool     `help:"Set all timestamps in the package to $SOURCE_DATE_EPOCH, or the Unix epoch if unset, so that identical inputs produce an identical digest."`
 }
 
 func (c *buildCmd) Help() string {
@@ -148,6 +155,13 @@ func (c *buildCmd) Run(p pterm.TextPrinter) error { //nolint:gocyclo
 		}
 		buildOpts = append(buildOpts, xpkg.WithController(base))
 	}
+	if c.Reproducible {
+		t, err := sourceDateEpoch()
+		if err != nil {
+			return err
+		}
+		buildOpts = append(buildOpts, xpkg.WithCreated(t))
+	}
 	img, meta, err := c.builder.Build(context.Background(), buildOpts...)
 	if err != nil {
 		return errors.Wrap(err, errBuildPackage)
@@ -184,6 +198,20 @@ func (c *buildCmd) Run(p pterm.TextPrinter) error { //nolint:gocyclo
 	return nil
 }
 
+// sourceDateEpoch returns the time set in the SOURCE_DATE_EPOCH environment
+// variable, or the Unix epoch if it is not set.
+func sourceDateEpoch() (time.Time, error) {
+	v, ok := os.LookupEnv(envSourceDateEpoch)
+	if !ok || v == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, errSourceDateEpoch)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
 // default build filters skip directories, empty files, and files without YAML
 // extension in addition to any paths specified.
 func buildFilters(root string, skips []string) []parser.FilterFn {
@@ -17,6 +17,7 @@ package xpkg
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 
 	"github.com/alecthomas/kong"
@@ -25,6 +26,9 @@ import (
 	"github.com/pterm/pterm"
 	"github.com/spf13/afero"
 
+	"github.com/upbound/up/internal/credhelper"
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/upbound"
 	"github.com/upbound/up/internal/xpkg"
 	"github.com/upbound/up/internal/xpkg/dep"
 	"github.com/upbound/up/internal/xpkg/dep/cache"
@@ -35,11 +39,15 @@ import (
 
 const (
 	errMetaFileNotFound = "crossplane.yaml file not found in current directory"
+	errUpdateOffline    = "--update requires network access and cannot be used with --offline"
 )
 
 // AfterApply constructs and binds Upbound-specific context to any subcommands
 // that have Run() methods that receive it.
-func (c *depCmd) AfterApply(kongCtx *kong.Context, p pterm.TextPrinter) error {
+func (c *depCmd) AfterApply(kongCtx *kong.Context, p pterm.TextPrinter, offline feature.Offline) error {
+	if c.Update && bool(offline) {
+		return errors.New(errUpdateOffline)
+	}
 	kongCtx.Bind(pterm.DefaultBulletList.WithWriter(kongCtx.Stdout))
 	ctx := context.Background()
 	fs := afero.NewOsFs()
@@ -53,12 +61,24 @@ func (c *depCmd) AfterApply(kongCtx *kong.Context, p pterm.TextPrinter) error {
 
 	// only parse the workspace if we aren't attempting to clean the cache
 	if !c.CleanCache {
+		upCtx, err := upbound.NewFromFlags(upbound.Flags{Domain: c.Domain, Profile: c.Profile}, upbound.AllowMissingProfile())
+		if err != nil {
+			return err
+		}
 
-		r := image.NewResolver()
+		r := image.NewResolver(
+			image.WithFetcher(image.NewLocalFetcher(
+				image.WithKeychain(credhelper.NewKeychain(
+					credhelper.WithDomain(upCtx.Domain.Hostname()),
+					credhelper.WithProfile(upCtx.ProfileName),
+				)),
+			)),
+		)
 
 		m, err := manager.New(
 			manager.WithCache(cache),
 			manager.WithResolver(r),
+			manager.WithOffline(bool(offline)),
 		)
 
 		if err != nil {
@@ -66,6 +86,7 @@ func (c *depCmd) AfterApply(kongCtx *kong.Context, p pterm.TextPrinter) error {
 		}
 
 		c.m = m
+		c.r = r
 
 		wd, err := os.Getwd()
 		if err != nil {
@@ -92,6 +113,7 @@ func (c *depCmd) AfterApply(kongCtx *kong.Context, p pterm.TextPrinter) error {
 type depCmd struct {
 	c  *cache.Local
 	m  *manager.Manager
+	r  *image.Resolver
 	ws *workspace.Workspace
 
 	// TODO(@tnthornton) remove cacheDir flag. Having a user supplied flag
@@ -99,8 +121,14 @@ type depCmd struct {
 	// only be supplied by the Config.
 	CacheDir   string `short:"d" help:"Directory used for caching package images." default:"~/.up/cache/" env:"CACHE_DIR" type:"path"`
 	CleanCache bool   `short:"c" help:"Clean dep cache."`
+	Update     bool   `short:"u" help:"Update the dependencies in crossplane.yaml to the newest compatible versions."`
 
 	Package string `arg:"" optional:"" help:"Package to be added."`
+
+	// The Upbound flags used to pull packages. Flags are not embedded as
+	// the short flag of --debug conflicts with that of --cache-dir.
+	Domain  *url.URL `env:"UP_DOMAIN" default:"https://upbound.io" help:"Root Upbound domain."`
+	Profile string   `env:"UP_PROFILE" help:"Profile used to pull packages." predictor:"profiles"`
 }
 
 func (c *depCmd) Help() string {
@@ -108,13 +136,25 @@ func (c *depCmd) Help() string {
 The dep command manages crossplane package dependencies of the package 
 in the current directory. It caches package information in a local file system
 cache (by default in ~/.up/cache), to be used e.g. for the Crossplane language
-server.
+server. With --offline, dependencies are resolved from the cache only.
 
 If a package (e.g. provider-foo@v0.42.0 or provider-foo for latest) is specified,
 it will be added to the crossplane.yaml file in the current directory as dependency. 
+
+With --update, dependencies pinned to an exact version in crossplane.yaml are
+updated to the newest version with the same major version, or with the same
+minor version for 0.x versions, and dependencies with a version range are
+resolved to the newest version in the range. The crossplane.yaml file and the
+cache are updated accordingly.
 `
 }
 
+// Mutating returns true if the dep command writes crossplane.yaml, i.e. when
+// adding a package or updating dependencies.
+func (c *depCmd) Mutating() bool {
+	return c.Update || c.Package != ""
+}
+
 // Run executes the dep command.
 func (c *depCmd) Run(ctx context.Context, p pterm.TextPrinter, pb *pterm.BulletListPrinter) error {
 	// no need to do anything else if clean cache was called.
@@ -137,6 +177,10 @@ func (c *depCmd) Run(ctx context.Context, p pterm.TextPrinter, pb *pterm.BulletL
 		return nil
 	}
 
+	if c.Update {
+		return c.update(ctx, p, pb)
+	}
+
 	deps, err := c.metaSuppliedDeps(ctx)
 	if err != nil {
 		return err
@@ -212,3 +256,61 @@ func (c *depCmd) metaSuppliedDeps(ctx context.Context) ([]v1beta1.Dependency, er
 
 	return resolvedDeps, nil
 }
+
+func (c *depCmd) update(ctx context.Context, p pterm.TextPrinter, pb *pterm.BulletListPrinter) error {
+	meta := c.ws.View().Meta()
+	if meta == nil {
+		return errors.New(errMetaFileNotFound)
+	}
+
+	deps, err := meta.DependsOn()
+	if err != nil {
+		return err
+	}
+	if len(deps) == 0 {
+		p.Printfln("No dependencies specified")
+		return nil
+	}
+
+	li := make([]pterm.BulletListItem, len(deps))
+	changed := false
+	for i, d := range deps {
+		u := d
+		u.Constraints = dep.CompatibleConstraint(d)
+		v, err := c.r.ResolveTag(ctx, u)
+		if err != nil {
+			return errors.Wrapf(err, "in %s", d.Package)
+		}
+		u.Constraints = v
+		if _, _, err := c.m.AddAll(ctx, u); err != nil {
+			return errors.Wrapf(err, "in %s", d.Package)
+		}
+
+		text := fmt.Sprintf("%s (%s) is up to date", d.Package, d.Constraints)
+		switch {
+		case d.Constraints == v:
+		case dep.CompatibleConstraint(d) != d.Constraints:
+			// The dependency is pinned to an exact version.
+			if err := meta.Upsert(u); err != nil {
+				return err
+			}
+			changed = true
+			text = fmt.Sprintf("%s updated from %s to %s", d.Package, d.Constraints, v)
+		default:
+			text = fmt.Sprintf("%s (%s) resolves to %s", d.Package, d.Constraints, v)
+		}
+		li[i] = pterm.BulletListItem{
+			Level:  0,
+			Text:   text,
+			Bullet: "-",
+		}
+	}
+
+	if changed {
+		if err := c.ws.Write(meta); err != nil {
+			return err
+		}
+	}
+	p.Printfln("Dependencies:")
+	return pb.WithItems(li).Render()
+}
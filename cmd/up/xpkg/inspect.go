@@ -0,0 +1,239 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pterm/pterm"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	xpextv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	xpmetav1 "github.com/crossplane/crossplane/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/credhelper"
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+	"github.com/upbound/up/internal/xpkg"
+	"github.com/upbound/up/internal/xpkg/dep/cache"
+	xpkgmarshaler "github.com/upbound/up/internal/xpkg/dep/marshaler/xpkg"
+	"github.com/upbound/up/internal/xpkg/scheme"
+)
+
+const (
+	errGetDigest    = "failed to get package digest"
+	errParsePackage = "failed to parse package"
+	errNotCached    = "package is not in the local cache, run \"up xpkg dep\" while online to populate it"
+	errCachedTag    = "only packages referenced by tag can be read from the local cache"
+)
+
+// AfterApply constructs and binds Upbound-specific context to any subcommands
+// that have Run() methods that receive it.
+func (c *inspectCmd) AfterApply(offline feature.Offline) error {
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	ref, err := name.ParseReference(c.Package, name.WithDefaultRegistry(upCtx.RegistryEndpoint.Hostname()))
+	if err != nil {
+		return errors.Wrap(err, errInvalidTag)
+	}
+	c.ref = ref
+	c.offline = bool(offline)
+	c.fetch = registryFetch(credhelper.NewKeychain(
+		credhelper.WithDomain(upCtx.Domain.Hostname()),
+		credhelper.WithProfile(upCtx.ProfileName),
+	))
+	return nil
+}
+
+// inspectCmd shows the metadata of a package in a registry.
+type inspectCmd struct {
+	ref     name.Reference
+	fetch   fetchFn
+	offline bool
+
+	Package  string `arg:"" help:"Name of the package to inspect. Must be a valid OCI image reference."`
+	CacheDir string `help:"Directory used for caching package images." default:"~/.up/cache/" env:"CACHE_DIR" type:"path"`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+func (c *inspectCmd) Help() string {
+	return `
+Shows the metadata of a package without downloading the full image. Only the
+image manifest and the layer annotated as the xpkg base layer are fetched from
+the registry.
+
+With --offline, or if the registry cannot be reached, the package is read from
+the local dependency cache populated by "up xpkg dep".`
+}
+
+// packageInfo is the metadata of a package.
+type packageInfo struct {
+	Package      string              `json:"package"`
+	Digest       string              `json:"digest"`
+	Type         string              `json:"type"`
+	Crossplane   string              `json:"crossplane,omitempty"`
+	Dependencies []packageDependency `json:"dependencies,omitempty"`
+	CRDs         []string            `json:"crds,omitempty"`
+	XRDs         []string            `json:"xrds,omitempty"`
+}
+
+// packageDependency is a dependency of a package.
+type packageDependency struct {
+	Package     string `json:"package"`
+	Type        string `json:"type"`
+	Constraints string `json:"constraints"`
+}
+
+var inspectFieldNames = []string{"PACKAGE", "TYPE", "CROSSPLANE", "DIGEST"}
+
+// Run executes the inspect command.
+func (c *inspectCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	var digest string
+	var pkg *xpkgmarshaler.ParsedPackage
+	var err error
+	if !c.offline {
+		digest, pkg, err = c.remotePackage(context.Background())
+	}
+	// Fall back to the local cache if the registry cannot be reached.
+	if c.offline || feature.IsNetworkError(err) {
+		digest, pkg, err = c.cachedPackage()
+	}
+	if err != nil {
+		return err
+	}
+
+	info := describePackage(c.ref.Name(), digest, pkg)
+	if err := printer.Print(info, inspectFieldNames, extractInspectFields); err != nil {
+		return err
+	}
+	if printer.Format != config.Default || printer.Quiet {
+		return nil
+	}
+	if len(info.Dependencies) > 0 {
+		p.Println()
+		p.Println("Dependencies:")
+		for _, d := range info.Dependencies {
+			p.Printfln("  %s (%s) %s", d.Package, d.Type, d.Constraints)
+		}
+	}
+	if len(info.CRDs) > 0 {
+		p.Println()
+		p.Println("CRDs:")
+		for _, n := range info.CRDs {
+			p.Printfln("  %s", n)
+		}
+	}
+	if len(info.XRDs) > 0 {
+		p.Println()
+		p.Println("XRDs:")
+		for _, n := range info.XRDs {
+			p.Printfln("  %s", n)
+		}
+	}
+	return nil
+}
+
+// remotePackage fetches the package from its registry.
+func (c *inspectCmd) remotePackage(ctx context.Context) (string, *xpkgmarshaler.ParsedPackage, error) {
+	img, err := c.fetch(ctx, c.ref)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errFetchPackage)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", nil, errors.Wrap(err, errGetDigest)
+	}
+	rc, err := xpkg.PackageStream(img)
+	if err != nil {
+		return "", nil, err
+	}
+	defer rc.Close() //nolint:errcheck
+	m, err := xpkgmarshaler.NewMarshaler()
+	if err != nil {
+		return "", nil, err
+	}
+	pkg, err := m.FromStream(rc)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errParsePackage)
+	}
+	return digest.String(), pkg, nil
+}
+
+// cachedPackage reads the package from the local dependency cache.
+func (c *inspectCmd) cachedPackage() (string, *xpkgmarshaler.ParsedPackage, error) {
+	tag, ok := c.ref.(name.Tag)
+	if !ok {
+		return "", nil, errors.New(errCachedTag)
+	}
+	ch, err := cache.NewLocal(c.CacheDir)
+	if err != nil {
+		return "", nil, err
+	}
+	pkg, err := ch.Get(v1beta1.Dependency{Package: tag.Repository.Name(), Constraints: tag.TagStr()})
+	if err != nil {
+		return "", nil, errors.Wrap(err, errNotCached)
+	}
+	return pkg.Digest(), pkg, nil
+}
+
+func describePackage(ref, digest string, pkg *xpkgmarshaler.ParsedPackage) packageInfo {
+	info := packageInfo{
+		Package: ref,
+		Digest:  digest,
+		Type:    string(pkg.Type()),
+	}
+	if meta, ok := scheme.TryConvertToPkg(pkg.Meta(), &xpmetav1.Provider{}, &xpmetav1.Configuration{}); ok {
+		if cs := meta.GetCrossplaneConstraints(); cs != nil {
+			info.Crossplane = cs.Version
+		}
+	}
+	for _, d := range pkg.Dependencies() {
+		info.Dependencies = append(info.Dependencies, packageDependency{
+			Package:     d.Package,
+			Type:        string(d.Type),
+			Constraints: d.Constraints,
+		})
+	}
+	for _, o := range pkg.Objects() {
+		switch obj := o.(type) {
+		case *extv1.CustomResourceDefinition:
+			info.CRDs = append(info.CRDs, obj.GetName())
+		case *extv1beta1.CustomResourceDefinition:
+			info.CRDs = append(info.CRDs, obj.GetName())
+		case *xpextv1.CompositeResourceDefinition:
+			info.XRDs = append(info.XRDs, obj.GetName())
+		}
+	}
+	return info
+}
+
+func extractInspectFields(obj any) []string {
+	info := obj.(packageInfo)
+	crossplane := info.Crossplane
+	if crossplane == "" {
+		crossplane = "n/a"
+	}
+	return []string{info.Package, info.Type, crossplane, info.Digest}
+}
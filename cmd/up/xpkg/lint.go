@@ -0,0 +1,289 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/parser"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pterm/pterm"
+	"github.com/spf13/afero"
+
+	"github.com/upbound/up/internal/credhelper"
+	"github.com/upbound/up/internal/feature"
+	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
+	"github.com/upbound/up/internal/version"
+	"github.com/upbound/up/internal/xpkg"
+	"github.com/upbound/up/internal/xpkg/parser/linter"
+	"github.com/upbound/up/internal/xpkg/parser/yaml"
+)
+
+const (
+	errParsePackageDir = "failed to parse package directory"
+	errWriteSARIF      = "failed to write SARIF report"
+	errLintFailed      = "package has lint errors"
+	errLintRefOffline  = "linting a package in a registry requires network access and cannot be used with --offline"
+
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// AfterApply constructs and binds Upbound-specific context to any subcommands
+// that have Run() methods that receive it.
+func (c *lintCmd) AfterApply(offline feature.Offline) error {
+	c.fs = afero.NewOsFs()
+	if fi, err := c.fs.Stat(c.Package); err == nil && fi.IsDir() {
+		root, err := filepath.Abs(c.Package)
+		if err != nil {
+			return err
+		}
+		c.load = c.loadDir(root)
+		return nil
+	}
+	if offline {
+		return errors.New(errLintRefOffline)
+	}
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	ref, err := name.ParseReference(c.Package, name.WithDefaultRegistry(upCtx.RegistryEndpoint.Hostname()))
+	if err != nil {
+		return errors.Wrap(err, errInvalidTag)
+	}
+	c.load = c.loadRef(ref, registryFetch(credhelper.NewKeychain(
+		credhelper.WithDomain(upCtx.Domain.Hostname()),
+		credhelper.WithProfile(upCtx.ProfileName),
+	)))
+	return nil
+}
+
+// lintCmd lints a package directory or a package in a registry.
+type lintCmd struct {
+	fs   afero.Fs
+	load func(context.Context) (linter.Package, error)
+
+	Package      string   `arg:"" default:"." help:"Path to a package directory or a package reference in a registry."`
+	ExamplesRoot string   `help:"Path to package examples directory. Only used when linting a directory." default:"./examples"`
+	AuthExt      string   `help:"Path to an authentication extension file. Only used when linting a directory." default:"auth.yaml"`
+	Ignore       []string `help:"Paths, specified relative to the package directory, to exclude from the package."`
+	SARIF        string   `name:"sarif" type:"path" placeholder:"PATH" help:"Write the findings as a SARIF report to the given path. Use - for stdout."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+func (c *lintCmd) Help() string {
+	return `
+The lint command validates a package before it is pushed. It checks the
+package metadata, the version constraints of its dependencies, that each type
+is defined only once, and the annotations used by the Upbound Marketplace.
+
+Findings are printed as a table by default, or as JSON or YAML with --format.
+A SARIF report for code scanning tools can be written with --sarif. The
+command fails if any finding has the error level.`
+}
+
+// Run executes the lint command.
+func (c *lintCmd) Run(printer upterm.ObjectPrinter, p pterm.TextPrinter) error {
+	pkg, err := c.load(context.Background())
+	if err != nil {
+		return err
+	}
+	diags := xpkg.Diagnose(pkg)
+
+	if c.SARIF != "" {
+		if err := c.writeSARIF(diags); err != nil {
+			return errors.Wrap(err, errWriteSARIF)
+		}
+	}
+	if c.SARIF != "-" {
+		if len(diags) == 0 {
+			p.Println("No issues found.")
+		} else if err := printer.Print(diags, lintFieldNames, extractLintFields); err != nil {
+			return err
+		}
+	}
+	for _, d := range diags {
+		if d.Level == xpkg.LevelError {
+			return errors.New(errLintFailed)
+		}
+	}
+	return nil
+}
+
+func (c *lintCmd) loadDir(root string) func(context.Context) (linter.Package, error) {
+	return func(ctx context.Context) (linter.Package, error) {
+		pp, err := yaml.New()
+		if err != nil {
+			return nil, err
+		}
+		be := parser.NewFsBackend(
+			c.fs,
+			parser.FsDir(root),
+			parser.FsFilters(
+				append(
+					buildFilters(root, c.Ignore),
+					xpkg.SkipContains(c.ExamplesRoot), xpkg.SkipContains(c.AuthExt))...),
+		)
+		r, err := be.Init(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, errParsePackageDir)
+		}
+		defer func() { _ = r.Close() }()
+		pkg, err := pp.Parse(ctx, r)
+		return pkg, errors.Wrap(err, errParsePackageDir)
+	}
+}
+
+func (c *lintCmd) loadRef(ref name.Reference, fetch fetchFn) func(context.Context) (linter.Package, error) {
+	return func(ctx context.Context) (linter.Package, error) {
+		img, err := fetch(ctx, ref)
+		if err != nil {
+			return nil, errors.Wrap(err, errFetchPackage)
+		}
+		rc, err := xpkg.PackageStream(img)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rc.Close() }()
+		pp, err := yaml.New()
+		if err != nil {
+			return nil, err
+		}
+		pkg, err := pp.Parse(ctx, rc)
+		return pkg, errors.Wrap(err, errParsePackage)
+	}
+}
+
+func (c *lintCmd) writeSARIF(diags []xpkg.Diagnostic) error {
+	var w io.Writer = os.Stdout
+	if c.SARIF != "-" {
+		f, err := c.fs.Create(c.SARIF)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toSARIF(c.Package, diags))
+}
+
+var lintFieldNames = []string{"LEVEL", "RULE", "OBJECT", "MESSAGE"}
+
+func extractLintFields(obj any) []string {
+	d := obj.(xpkg.Diagnostic)
+	return []string{d.Level, d.Rule, d.Object, d.Message}
+}
+
+// sarifLog is the subset of the SARIF 2.1.0 format written by the lint
+// command.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func toSARIF(pkg string, diags []xpkg.Diagnostic) sarifLog {
+	rules := []sarifRule{}
+	seen := map[string]bool{}
+	results := make([]sarifResult, len(diags))
+	for i, d := range diags {
+		if !seen[d.Rule] {
+			seen[d.Rule] = true
+			rules = append(rules, sarifRule{ID: d.Rule})
+		}
+		loc := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: pkg}},
+		}
+		if d.Object != "" {
+			loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: d.Object}}
+		}
+		results[i] = sarifResult{
+			RuleID:    d.Rule,
+			Level:     d.Level,
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{loc},
+		}
+	}
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:    "up",
+				Version: version.GetVersion(),
+				Rules:   rules,
+			}},
+			Results: results,
+		}},
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/input"
+	"github.com/upbound/up/internal/upbound"
+)
+
+const (
+	loginTimeout = 30 * time.Second
+
+	errInvalidRegistry = "invalid registry"
+	errRegistryAuth    = "unable to authenticate to registry"
+	errNoProfile       = "no profile to store registry credentials in, login to Upbound or create a profile first"
+	errUpdateConfig    = "unable to update config file"
+)
+
+// BeforeApply sets default values in login before assignment and validation.
+func (c *loginCmd) BeforeApply() error { //nolint:unparam
+	c.stdin = os.Stdin
+	c.prompter = input.NewPrompter()
+	return nil
+}
+
+// AfterApply constructs and binds Upbound-specific context to any subcommands
+// that have Run() methods that receive it.
+func (c *loginCmd) AfterApply(kongCtx *kong.Context) error {
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	kongCtx.Bind(upCtx)
+	if c.Username == "" {
+		username, err := c.prompter.Prompt("Username", false)
+		if err != nil {
+			return err
+		}
+		c.Username = username
+	}
+	if c.Password == "" {
+		password, err := c.prompter.Prompt("Password", true)
+		if err != nil {
+			return err
+		}
+		c.Password = password
+	}
+	return nil
+}
+
+// loginCmd stores credentials for an OCI registry in the current profile.
+type loginCmd struct {
+	stdin    io.Reader
+	prompter input.Prompter
+
+	Registry string `arg:"" required:"" help:"Hostname of the OCI registry, e.g. ghcr.io."`
+
+	Username string `short:"u" env:"UP_REGISTRY_USER" help:"Username for the registry."`
+	Password string `short:"p" env:"UP_REGISTRY_PASSWORD" help:"Password for the registry. '-' to read from stdin."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+// Run executes the login command.
+func (c *loginCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
+	if c.Password == "-" {
+		b, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return err
+		}
+		c.Password = strings.TrimSpace(string(b))
+	}
+	if upCtx.ProfileName == "" {
+		return errors.New(errNoProfile)
+	}
+	reg, err := name.NewRegistry(c.Registry)
+	if err != nil {
+		return errors.Wrap(err, errInvalidRegistry)
+	}
+
+	// Establishing a transport pings the registry and exchanges the
+	// credentials for a token if the registry requires it.
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+	auth := authn.FromConfig(authn.AuthConfig{Username: c.Username, Password: c.Password})
+	if _, err := transport.NewWithContext(ctx, reg, auth, http.DefaultTransport, nil); err != nil {
+		return errors.Wrap(err, errRegistryAuth)
+	}
+
+	if err := upCtx.Cfg.SetRegistryCredentials(upCtx.ProfileName, reg.RegistryStr(), config.RegistryCredentials{
+		Username: c.Username,
+		Password: c.Password,
+	}); err != nil {
+		return err
+	}
+	if err := upCtx.CfgSrc.UpdateConfig(upCtx.Cfg); err != nil {
+		return errors.Wrap(err, errUpdateConfig)
+	}
+	p.Printfln("Logged in to %s", reg.RegistryStr())
+	return nil
+}
+
+// AfterApply constructs and binds Upbound-specific context to any subcommands
+// that have Run() methods that receive it.
+func (c *logoutCmd) AfterApply(kongCtx *kong.Context) error {
+	upCtx, err := upbound.NewFromFlags(c.Flags)
+	if err != nil {
+		return err
+	}
+	kongCtx.Bind(upCtx)
+	return nil
+}
+
+// logoutCmd removes credentials for an OCI registry from the current profile.
+type logoutCmd struct {
+	Registry string `arg:"" required:"" help:"Hostname of the OCI registry, e.g. ghcr.io."`
+
+	// Common Upbound API configuration
+	Flags upbound.Flags `embed:""`
+}
+
+// Run executes the logout command.
+func (c *logoutCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error {
+	reg, err := name.NewRegistry(c.Registry)
+	if err != nil {
+		return errors.Wrap(err, errInvalidRegistry)
+	}
+	if err := upCtx.Cfg.RemoveRegistryCredentials(upCtx.ProfileName, reg.RegistryStr()); err != nil {
+		return err
+	}
+	if err := upCtx.CfgSrc.UpdateConfig(upCtx.Cfg); err != nil {
+		return errors.Wrap(err, errUpdateConfig)
+	}
+	p.Printfln("Logged out of %s", reg.RegistryStr())
+	return nil
+}
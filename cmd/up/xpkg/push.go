@@ -20,10 +20,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
-	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
@@ -32,11 +33,14 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/pterm/pterm"
 	"github.com/spf13/afero"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/upbound/up-sdk-go/service/repositories"
 	"github.com/upbound/up/internal/credhelper"
+	"github.com/upbound/up/internal/tracing"
 	"github.com/upbound/up/internal/upbound"
+	"github.com/upbound/up/internal/upterm"
 	"github.com/upbound/up/internal/xpkg"
 )
 
@@ -47,11 +51,22 @@ const (
 	errGetwd             = "failed to get working directory while searching for package"
 	errFindPackageinWd   = "failed to find a package in current working directory"
 	errBuildImage        = "failed to build image from layers"
+	errNegativeRetries   = "--retries must not be negative"
+)
+
+const (
+	retryInterval  = time.Second
+	retryFactor    = 3.0
+	retryJitter    = 0.1
+	progressBuffer = 64
 )
 
 // AfterApply constructs and binds Upbound-specific context to any subcommands
 // that have Run() methods that receive it.
 func (c *pushCmd) AfterApply(kongCtx *kong.Context) error {
+	if c.Retries < 0 {
+		return errors.New(errNegativeRetries)
+	}
 	c.fs = afero.NewOsFs()
 	upCtx, err := upbound.NewFromFlags(c.Flags)
 	if err != nil {
@@ -68,11 +83,20 @@ type pushCmd struct {
 	Tag     string   `arg:"" help:"Tag of the package to be pushed. Must be a valid OCI image tag."`
 	Package []string `short:"f" help:"Path to packages. If not specified and only one package exists in current directory it will be used."`
 	Create  bool     `help:"Create repository on push if it does not exist."`
+	Retries int      `default:"3" help:"Number of times to retry a request that failed with a transient registry error."`
 
 	// Common Upbound API configuration
 	Flags upbound.Flags `embed:""`
 }
 
+func (c *pushCmd) Help() string {
+	return `
+The push command pushes one or more packages to a registry, displaying the
+overall progress of the upload. Requests that fail with a transient registry
+error are retried with exponential backoff. Layers are uploaded whole, and
+layers that already exist in the registry are not uploaded again.`
+}
+
 // Run runs the push cmd.
 func (c *pushCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error { //nolint:gocyclo
 	// If package is not defined, attempt to find single package in current
@@ -97,24 +121,42 @@ func (c *pushCmd) Run(p pterm.TextPrinter, upCtx *upbound.Context) error { //nol
 		}
 		imgs = append(imgs, img)
 	}
-	return PushImages(p, upCtx, imgs, c.Tag, c.Create, c.Flags.Profile)
+	ctx, span := tracing.Start("xpkg push", attribute.String("xpkg.tag", c.Tag), attribute.Int("xpkg.images", len(imgs)))
+	err := upterm.WrapWithProgressBar(fmt.Sprintf("Pushing %s", c.Tag), func(progress func(int)) error {
+		prog := newPushProgress(len(imgs), progress)
+		err := PushImages(ctx, upCtx, imgs, c.Tag, c.Create, c.Flags.Profile,
+			prog.options,
+			// NOTE: Steps is the total number of attempts, not the number of
+			// retries.
+			remote.WithRetryBackoff(remote.Backoff{Duration: retryInterval, Factor: retryFactor, Jitter: retryJitter, Steps: c.Retries + 1}),
+		)
+		// Wait for all progress updates to be reported before the progress
+		// bar is stopped.
+		prog.wait()
+		return err
+	})
+	tracing.End(span, err)
+	if err != nil {
+		return err
+	}
+	p.Printfln("xpkg pushed to %s", c.Tag)
+	return nil
 }
 
-func PushImages(p pterm.TextPrinter, upCtx *upbound.Context, imgs []v1.Image, t string, create bool, profile string) error { //nolint:gocyclo
+// PushImages pushes the supplied images to the supplied tag, writing an index
+// if more than one image is supplied. If imgOpts is not nil it is called with
+// the index of each image to supply options for writing that image only.
+func PushImages(ctx context.Context, upCtx *upbound.Context, imgs []v1.Image, t string, create bool, profile string, imgOpts func(i int) []remote.Option, opts ...remote.Option) error { //nolint:gocyclo
 	tag, err := name.NewTag(t, name.WithDefaultRegistry(upCtx.RegistryEndpoint.Hostname()))
 	if err != nil {
 		return err
 	}
 
-	kc := authn.NewMultiKeychain(
-		authn.NewKeychainFromHelper(
-			credhelper.New(
-				credhelper.WithDomain(upCtx.Domain.Hostname()),
-				credhelper.WithProfile(profile),
-			),
-		),
-		authn.DefaultKeychain,
+	kc := credhelper.NewKeychain(
+		credhelper.WithDomain(upCtx.Domain.Hostname()),
+		credhelper.WithProfile(profile),
 	)
+	tr := tracing.Transport(remote.DefaultTransport)
 
 	if create {
 		if !strings.Contains(tag.RegistryStr(), upCtx.RegistryEndpoint.Hostname()) {
@@ -128,7 +170,7 @@ func PushImages(p pterm.TextPrinter, upCtx *upbound.Context, imgs []v1.Image, t
 		if err != nil {
 			return err
 		}
-		if err := repositories.NewClient(cfg).CreateOrUpdate(context.Background(), parts[0], parts[1]); err != nil {
+		if err := repositories.NewClient(cfg).CreateOrUpdate(ctx, parts[0], parts[1]); err != nil {
 			return errors.Wrap(err, errCreateRepo)
 		}
 	}
@@ -137,7 +179,7 @@ func PushImages(p pterm.TextPrinter, upCtx *upbound.Context, imgs []v1.Image, t
 
 	// NOTE(hasheddan): the errgroup context is passed to each image write,
 	// meaning that if one fails it will cancel others that are in progress.
-	g, ctx := errgroup.WithContext(context.Background())
+	g, gctx := errgroup.WithContext(ctx)
 	for i, img := range imgs {
 		// pin range variables for use in go func
 		i, img := i, img
@@ -181,7 +223,11 @@ func PushImages(p pterm.TextPrinter, upCtx *upbound.Context, imgs []v1.Image, t
 					},
 				}
 			}
-			if err := remote.Write(t, aimg, remote.WithAuthFromKeychain(kc), remote.WithContext(ctx)); err != nil {
+			wopts := append([]remote.Option{remote.WithAuthFromKeychain(kc), remote.WithContext(gctx), remote.WithTransport(tr)}, opts...)
+			if imgOpts != nil {
+				wopts = append(wopts, imgOpts(i)...)
+			}
+			if err := remote.Write(t, aimg, wopts...); err != nil {
 				return err
 			}
 			return nil
@@ -195,15 +241,65 @@ func PushImages(p pterm.TextPrinter, upCtx *upbound.Context, imgs []v1.Image, t
 
 	// If we pushed more than one xpkg then we need to write index.
 	if len(imgs) > 1 {
-		if err := remote.WriteIndex(tag, mutate.AppendManifests(empty.Index, adds...), remote.WithAuthFromKeychain(kc)); err != nil {
+		if err := remote.WriteIndex(tag, mutate.AppendManifests(empty.Index, adds...), append([]remote.Option{remote.WithAuthFromKeychain(kc), remote.WithContext(ctx), remote.WithTransport(tr)}, opts...)...); err != nil {
 			return err
 		}
 	}
-
-	p.Printfln("xpkg pushed to %s", tag.String())
 	return nil
 }
 
+// pushProgress aggregates the upload progress of multiple images into a
+// single percentage.
+type pushProgress struct {
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	progress func(pct int)
+	total    []int64
+	complete []int64
+}
+
+func newPushProgress(n int, progress func(pct int)) *pushProgress {
+	return &pushProgress{progress: progress, total: make([]int64, n), complete: make([]int64, n)}
+}
+
+// options returns the write options reporting the progress of the image at
+// the supplied index.
+func (pp *pushProgress) options(i int) []remote.Option {
+	updates := make(chan v1.Update, progressBuffer)
+	pp.wg.Add(1)
+	go func() {
+		defer pp.wg.Done()
+		// NOTE: the channel is closed once the image has been written.
+		for u := range updates {
+			pp.update(i, u)
+		}
+	}()
+	return []remote.Option{remote.WithProgress(updates)}
+}
+
+// wait waits until the updates of all images have been reported.
+func (pp *pushProgress) wait() {
+	pp.wg.Wait()
+}
+
+func (pp *pushProgress) update(i int, u v1.Update) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+	if u.Error != nil {
+		return
+	}
+	pp.total[i], pp.complete[i] = u.Total, u.Complete
+	var total, complete int64
+	for j := range pp.total {
+		total += pp.total[j]
+		complete += pp.complete[j]
+	}
+	if total == 0 {
+		return
+	}
+	pp.progress(int(complete * 100 / total))
+}
+
 // annotate reads in the layers of the given v1.Image and annotates the xpkg
 // layers with their corresponding annotations, returning a new v1.Image
 // containing the annotation details.
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/daemon"
@@ -32,6 +33,7 @@ import (
 	"github.com/pterm/pterm"
 	"github.com/spf13/afero"
 
+	"github.com/upbound/up/internal/credhelper"
 	"github.com/upbound/up/internal/upbound"
 	"github.com/upbound/up/internal/xpkg"
 )
@@ -60,8 +62,10 @@ const (
 type fetchFn func(context.Context, name.Reference) (v1.Image, error)
 
 // registryFetch fetches a package from the registry.
-func registryFetch(ctx context.Context, r name.Reference) (v1.Image, error) {
-	return remote.Image(r, remote.WithContext(ctx))
+func registryFetch(kc authn.Keychain) fetchFn {
+	return func(ctx context.Context, r name.Reference) (v1.Image, error) {
+		return remote.Image(r, remote.WithContext(ctx), remote.WithAuthFromKeychain(kc))
+	}
 }
 
 // daemonFetch fetches a package from the Docker daemon.
@@ -79,7 +83,6 @@ func xpkgFetch(path string) fetchFn {
 // that have Run() methods that receive it.
 func (c *xpExtractCmd) AfterApply() error {
 	c.fs = afero.NewOsFs()
-	c.fetch = registryFetch
 	if c.FromDaemon {
 		c.fetch = daemonFetch
 	}
@@ -112,6 +115,12 @@ func (c *xpExtractCmd) AfterApply() error {
 			return errors.Wrap(err, errInvalidTag)
 		}
 		c.name = name
+		if !c.FromDaemon {
+			c.fetch = registryFetch(authn.NewMultiKeychain(
+				credhelper.NewRegistryKeychain(credhelper.WithProfile(c.Flags.Profile)),
+				authn.DefaultKeychain,
+			))
+		}
 	}
 	return nil
 }
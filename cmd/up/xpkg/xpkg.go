@@ -27,11 +27,15 @@ func (c *Cmd) BeforeReset(p *kong.Path, maturity feature.Maturity) error {
 
 // Cmd contains commands for interacting with xpkgs.
 type Cmd struct {
-	Build     buildCmd     `cmd:"" help:"Build a package, by default from the current directory."`
+	Build     buildCmd     `cmd:"" offline:"" help:"Build a package, by default from the current directory."`
 	XPExtract xpExtractCmd `cmd:"" maturity:"alpha" help:"Extract package contents into a Crossplane cache compatible format. Fetches from a remote registry by default."`
-	Init      initCmd      `cmd:"" help:"Initialize a package, by default in the current directory."`
-	Dep       depCmd       `cmd:"" help:"Manage package dependencies in the filesystem and populate the cache, e.g. used by the Crossplane Language Server."`
+	Init      initCmd      `cmd:"" offline:"" help:"Initialize a package, by default in the current directory."`
+	Dep       depCmd       `cmd:"" offline:"" help:"Manage package dependencies in the filesystem and populate the cache, e.g. used by the Crossplane Language Server."`
 	Push      pushCmd      `cmd:"" help:"Push a package."`
+	Inspect   inspectCmd   `cmd:"" offline:"" help:"Show the metadata of a package in a registry."`
+	Lint      lintCmd      `cmd:"" offline:"" help:"Lint a package directory or a package in a registry."`
+	Login     loginCmd     `cmd:"" help:"Store credentials for an OCI registry in the current profile."`
+	Logout    logoutCmd    `cmd:"" help:"Remove credentials for an OCI registry from the current profile."`
 	Batch     batchCmd     `cmd:"" maturity:"alpha" help:"Batch build and push a family of service-scoped provider packages."`
 }
 
@@ -0,0 +1,184 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the mutating commands run with up in a local log.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/up/internal/config"
+)
+
+// File is the name of the audit log in the up config directory.
+const File = "audit.jsonl"
+
+// Results of a command.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+const (
+	redacted = "<redacted>"
+
+	errOpenLog   = "unable to open audit log"
+	errWriteLog  = "unable to write audit log"
+	errReadLog   = "unable to read audit log"
+	errFmtDecode = "unable to decode audit log line %d"
+)
+
+// mutatingVerbs are the subcommand names of commands that change state.
+var mutatingVerbs = map[string]bool{
+	"apply":     true,
+	"batch":     true,
+	"configure": true,
+	"connect":   true,
+	"create":    true,
+	"delete":    true,
+	"destroy":   true,
+	"disable":   true,
+	"enable":    true,
+	"import":    true,
+	"init":      true,
+	"install":   true,
+	"invite":    true,
+	"login":     true,
+	"logout":    true,
+	"push":      true,
+	"remove":    true,
+	"restart":   true,
+	"rotate":    true,
+	"uninstall": true,
+	"update":    true,
+	"upgrade":   true,
+}
+
+// mutatingLeafVerbs are the names of commands that change state, but are also
+// the names of command groups that contain read-only commands, e.g. robot
+// token list. They only count as mutating as the selected command.
+var mutatingLeafVerbs = map[string]bool{
+	"token": true,
+}
+
+// sensitiveFlags are substrings of the names of flags whose values are never
+// recorded.
+var sensitiveFlags = []string{"token", "password", "secret", "key", "literal", "header", "body"}
+
+// An Entry records a single command.
+type Entry struct {
+	Time       time.Time         `json:"time"`
+	Command    string            `json:"command"`
+	Target     []string          `json:"target,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Result     string            `json:"result"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// IsMutating returns true if the supplied command, given as its subcommand
+// names, changes state and should be recorded.
+func IsMutating(command []string) bool {
+	for _, c := range command {
+		if mutatingVerbs[c] {
+			return true
+		}
+	}
+	return len(command) > 0 && mutatingLeafVerbs[command[len(command)-1]]
+}
+
+// Redact returns the value to record for the supplied flag.
+func Redact(flag, value string) string {
+	f := strings.ToLower(flag)
+	for _, s := range sensitiveFlags {
+		if strings.Contains(f, s) {
+			return redacted
+		}
+	}
+	return value
+}
+
+// DefaultPath returns the path of the audit log in the up config directory.
+func DefaultPath() (string, error) {
+	p, err := config.GetDefaultPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(p), File), nil
+}
+
+// A Log is an append-only audit log of JSON lines.
+type Log struct {
+	path string
+}
+
+// NewLog constructs a Log at the supplied path.
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// Record appends the supplied entry to the log.
+func (l *Log) Record(e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return errors.Wrap(err, errOpenLog)
+	}
+	f, err := os.OpenFile(filepath.Clean(l.path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, errOpenLog)
+	}
+	defer f.Close() //nolint:errcheck,gosec
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, errWriteLog)
+	}
+	_, err = f.Write(append(b, '\n'))
+	return errors.Wrap(err, errWriteLog)
+}
+
+// Read returns the entries of the log recorded at or after the supplied time.
+// A missing log has no entries.
+func (l *Log) Read(since time.Time) ([]Entry, error) {
+	f, err := os.Open(filepath.Clean(l.path))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errOpenLog)
+	}
+	defer f.Close() //nolint:errcheck,gosec
+
+	entries := []Entry{}
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; s.Scan(); n++ {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		e := Entry{}
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, errors.Wrapf(err, errFmtDecode, n)
+		}
+		if e.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, errors.Wrap(s.Err(), errReadLog)
+}
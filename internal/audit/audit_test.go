@@ -0,0 +1,102 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsMutating(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		command []string
+		want    bool
+	}{
+		"Create": {
+			reason:  "Commands that create resources should be recorded.",
+			command: []string{"controlplane", "create"},
+			want:    true,
+		},
+		"Push": {
+			reason:  "Pushing a package should be recorded.",
+			command: []string{"xpkg", "push"},
+			want:    true,
+		},
+		"Restart": {
+			reason:  "Restarting deployments inside a control plane should be recorded.",
+			command: []string{"controlplane", "restart"},
+			want:    true,
+		},
+		"Login": {
+			reason:  "Logging in rewrites stored credentials and should be recorded.",
+			command: []string{"login"},
+			want:    true,
+		},
+		"Token": {
+			reason:  "Minting a control plane token should be recorded.",
+			command: []string{"controlplane", "token"},
+			want:    true,
+		},
+		"ListTokens": {
+			reason:  "Listing tokens of a robot should not be recorded.",
+			command: []string{"robot", "token", "list"},
+			want:    false,
+		},
+		"List": {
+			reason:  "Read-only commands should not be recorded.",
+			command: []string{"controlplane", "list"},
+			want:    false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsMutating(tc.command); got != tc.want {
+				t.Errorf("\n%s\nIsMutating(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordRead(t *testing.T) {
+	l := NewLog(filepath.Join(t.TempDir(), "nested", File))
+	now := time.Now().UTC().Truncate(time.Second)
+	old := Entry{Time: now.Add(-48 * time.Hour), Command: "controlplane delete", Target: []string{"old"}, Result: ResultSuccess}
+	recent := Entry{
+		Time:       now,
+		Command:    "controlplane create",
+		Target:     []string{"new"},
+		Parameters: map[string]string{"token": Redact("token", "secret-value")},
+		Result:     ResultFailure,
+		Error:      "boom",
+	}
+	for _, e := range []Entry{old, recent} {
+		if err := l.Record(e); err != nil {
+			t.Fatalf("Record(...): %v", err)
+		}
+	}
+	got, err := l.Read(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Read(...): %v", err)
+	}
+	want := []Entry{recent}
+	want[0].Parameters = map[string]string{"token": redacted}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read(...): -want, +got:\n%s", diff)
+	}
+}
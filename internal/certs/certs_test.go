@@ -0,0 +1,103 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certs
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIssue(t *testing.T) {
+	ca, err := NewCA("test-ca", time.Hour)
+	if err != nil {
+		t.Fatalf("NewCA(...): %v", err)
+	}
+	certPEM, _, err := ca.Issue("router", []string{"proxy.example.com"}, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue(...): %v", err)
+	}
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		t.Fatalf("ParseCertificate(...): %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	if _, err := cert.Verify(x509.VerifyOptions{DNSName: "proxy.example.com", Roots: pool}); err != nil {
+		t.Errorf("Verify(...): issued certificate is not trusted by its CA: %v", err)
+	}
+}
+
+func TestLoadCA(t *testing.T) {
+	ca, err := NewCA("test-ca", time.Hour)
+	if err != nil {
+		t.Fatalf("NewCA(...): %v", err)
+	}
+	leafPEM, leafKeyPEM, err := ca.Issue("leaf", nil, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue(...): %v", err)
+	}
+	other, err := NewCA("other-ca", time.Hour)
+	if err != nil {
+		t.Fatalf("NewCA(...): %v", err)
+	}
+	_, otherKeyPEM, err := other.Issue("other", nil, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue(...): %v", err)
+	}
+
+	cases := map[string]struct {
+		reason  string
+		certPEM []byte
+		keyPEM  []byte
+		err     error
+	}{
+		"Valid": {
+			reason:  "A generated CA should be loaded from its own certificate and private key.",
+			certPEM: ca.CertPEM,
+			keyPEM:  ca.KeyPEM,
+		},
+		"NotCA": {
+			reason:  "A certificate that is not a CA should be rejected.",
+			certPEM: leafPEM,
+			keyPEM:  leafKeyPEM,
+			err:     errors.New(errNotCA),
+		},
+		"KeyMismatch": {
+			reason:  "A private key that does not belong to the certificate should be rejected.",
+			certPEM: ca.CertPEM,
+			keyPEM:  otherKeyPEM,
+			err:     errors.New(errKeyMismatch),
+		},
+		"InvalidKey": {
+			reason:  "A private key that is not PEM encoded should be rejected.",
+			certPEM: ca.CertPEM,
+			keyPEM:  []byte("nope"),
+			err:     errors.New(errDecodeKey),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadCA(tc.certPEM, tc.keyPEM)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nLoadCA(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
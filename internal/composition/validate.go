@@ -0,0 +1,230 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package composition contains utilities for working with Crossplane
+// Compositions.
+package composition
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpextv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+const (
+	errFmtFieldNotFound = "field %q not found in schema"
+	errFmtNotArray      = "field %q is not an array"
+)
+
+// An Issue is a problem found in a Composition.
+type Issue struct {
+	// Resource is the name, or index if unnamed, of the composed template.
+	Resource string `json:"resource"`
+	// Path is the field path the issue refers to, if any.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// A Validator validates Compositions against the schemas of the resources
+// they compose.
+type Validator struct {
+	schemas map[schema.GroupVersionKind]*extv1.JSONSchemaProps
+}
+
+// NewValidator constructs a Validator for the supplied
+// CustomResourceDefinitions.
+func NewValidator(crds ...*extv1.CustomResourceDefinition) *Validator {
+	v := &Validator{
+		schemas: map[schema.GroupVersionKind]*extv1.JSONSchemaProps{},
+	}
+	for _, crd := range crds {
+		for _, ver := range crd.Spec.Versions {
+			if ver.Schema == nil || ver.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: ver.Name, Kind: crd.Spec.Names.Kind}
+			v.schemas[gvk] = ver.Schema.OpenAPIV3Schema
+		}
+	}
+	return v
+}
+
+// Validate checks that the base of each composed template matches the schema
+// of its kind, and that the field paths patched to and from the composed
+// resource exist in that schema.
+func (v *Validator) Validate(comp *xpextv1.Composition) []Issue {
+	patchSets := map[string][]xpextv1.Patch{}
+	for _, ps := range comp.Spec.PatchSets {
+		patchSets[ps.Name] = ps.Patches
+	}
+
+	issues := []Issue{}
+	for i, t := range comp.Spec.Resources {
+		res := strconv.Itoa(i)
+		if t.Name != nil {
+			res = *t.Name
+		}
+		base := map[string]any{}
+		if err := json.Unmarshal(t.Base.Raw, &base); err != nil {
+			issues = append(issues, Issue{Resource: res, Message: fmt.Sprintf("cannot decode base: %s", err)})
+			continue
+		}
+		apiVersion, _ := base["apiVersion"].(string)
+		kind, _ := base["kind"].(string)
+		gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+		s, ok := v.schemas[gvk]
+		if !ok {
+			issues = append(issues, Issue{Resource: res, Message: fmt.Sprintf("no schema found for %s, %s", apiVersion, kind)})
+			continue
+		}
+		for _, p := range unknownFields(s, base, "") {
+			issues = append(issues, Issue{Resource: res, Path: p, Message: "unknown field in base"})
+		}
+		for _, p := range composedPaths(t.Patches, patchSets) {
+			if err := checkPath(s, p); err != nil {
+				issues = append(issues, Issue{Resource: res, Path: p, Message: err.Error()})
+			}
+		}
+	}
+	return issues
+}
+
+// composedPaths returns the field paths of the composed resource that are
+// read or written by the supplied patches.
+func composedPaths(patches []xpextv1.Patch, patchSets map[string][]xpextv1.Patch) []string {
+	paths := []string{}
+	for _, p := range patches {
+		switch p.GetType() {
+		case xpextv1.PatchTypeFromCompositeFieldPath, xpextv1.PatchTypeFromEnvironmentFieldPath,
+			xpextv1.PatchTypeCombineFromComposite, xpextv1.PatchTypeCombineFromEnvironment:
+			if p.ToFieldPath != nil {
+				paths = append(paths, *p.ToFieldPath)
+			} else if p.FromFieldPath != nil {
+				// The composed field path defaults to the composite one.
+				paths = append(paths, *p.FromFieldPath)
+			}
+		case xpextv1.PatchTypeToCompositeFieldPath, xpextv1.PatchTypeToEnvironmentFieldPath:
+			if p.FromFieldPath != nil {
+				paths = append(paths, *p.FromFieldPath)
+			}
+		case xpextv1.PatchTypeCombineToComposite, xpextv1.PatchTypeCombineToEnvironment:
+			if p.Combine != nil {
+				for _, cv := range p.Combine.Variables {
+					paths = append(paths, cv.FromFieldPath)
+				}
+			}
+		case xpextv1.PatchTypePatchSet:
+			if p.PatchSetName != nil {
+				// Patch sets may not reference other patch sets.
+				paths = append(paths, composedPaths(patchSets[*p.PatchSetName], nil)...)
+			}
+		}
+	}
+	return paths
+}
+
+// checkPath returns an error if the supplied field path does not exist in the
+// supplied schema.
+func checkPath(s *extv1.JSONSchemaProps, path string) error {
+	segments, err := fieldpath.Parse(path)
+	if err != nil {
+		return err
+	}
+	// Object metadata is not part of the schema of a CRD.
+	if len(segments) > 0 && segments[0].Type == fieldpath.SegmentField && segments[0].Field == "metadata" {
+		return nil
+	}
+	for i, seg := range segments {
+		if s == nil || s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields {
+			return nil
+		}
+		switch seg.Type {
+		case fieldpath.SegmentField:
+			next, ok := property(s, seg.Field)
+			if !ok {
+				return errors.Errorf(errFmtFieldNotFound, segments[:i+1].String())
+			}
+			s = next
+		case fieldpath.SegmentIndex:
+			if s.Type != "array" {
+				return errors.Errorf(errFmtNotArray, segments[:i].String())
+			}
+			if s.Items == nil {
+				return nil
+			}
+			s = s.Items.Schema
+		}
+	}
+	return nil
+}
+
+// unknownFields returns the paths of the fields in the supplied object that
+// are not part of the supplied schema.
+func unknownFields(s *extv1.JSONSchemaProps, obj any, path string) []string {
+	if s == nil || s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields {
+		return nil
+	}
+	unknown := []string{}
+	switch o := obj.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if path == "" && (k == "apiVersion" || k == "kind" || k == "metadata") {
+				continue
+			}
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			next, ok := property(s, k)
+			if !ok {
+				unknown = append(unknown, p)
+				continue
+			}
+			unknown = append(unknown, unknownFields(next, o[k], p)...)
+		}
+	case []any:
+		if s.Items == nil {
+			return nil
+		}
+		for i, e := range o {
+			unknown = append(unknown, unknownFields(s.Items.Schema, e, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return unknown
+}
+
+// property returns the schema of the named property of the supplied object
+// schema. A nil schema is returned for properties that may have any value.
+func property(s *extv1.JSONSchemaProps, name string) (*extv1.JSONSchemaProps, bool) {
+	if p, ok := s.Properties[name]; ok {
+		return &p, true
+	}
+	if ap := s.AdditionalProperties; ap != nil && (ap.Allows || ap.Schema != nil) {
+		return ap.Schema, true
+	}
+	return nil, false
+}
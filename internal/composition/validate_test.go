@@ -0,0 +1,150 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composition
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	xpextv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+func bucketCRD() *extv1.CustomResourceDefinition {
+	str := extv1.JSONSchemaProps{Type: "string"}
+	return &extv1.CustomResourceDefinition{
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Group: "s3.aws.upbound.io",
+			Names: extv1.CustomResourceDefinitionNames{Kind: "Bucket"},
+			Versions: []extv1.CustomResourceDefinitionVersion{{
+				Name: "v1beta1",
+				Schema: &extv1.CustomResourceValidation{
+					OpenAPIV3Schema: &extv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]extv1.JSONSchemaProps{
+									"forProvider": {
+										Type: "object",
+										Properties: map[string]extv1.JSONSchemaProps{
+											"region": str,
+											"tags": {
+												Type:                 "object",
+												AdditionalProperties: &extv1.JSONSchemaPropsOrBool{Allows: true, Schema: &str},
+											},
+											"grants": {
+												Type: "array",
+												Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{
+													Type:       "object",
+													Properties: map[string]extv1.JSONSchemaProps{"id": str},
+												}},
+											},
+										},
+									},
+								},
+							},
+							"status": {
+								Type: "object",
+								Properties: map[string]extv1.JSONSchemaProps{
+									"atProvider": {
+										Type:       "object",
+										Properties: map[string]extv1.JSONSchemaProps{"arn": str},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	base := func(s string) runtime.RawExtension {
+		return runtime.RawExtension{Raw: []byte(s)}
+	}
+
+	cases := map[string]struct {
+		reason string
+		comp   *xpextv1.Composition
+		want   []Issue
+	}{
+		"Valid": {
+			reason: "A composition matching the schema should not have issues.",
+			comp: &xpextv1.Composition{Spec: xpextv1.CompositionSpec{
+				PatchSets: []xpextv1.PatchSet{{
+					Name:    "common",
+					Patches: []xpextv1.Patch{{FromFieldPath: pointer.String("metadata.labels"), ToFieldPath: pointer.String("metadata.labels")}},
+				}},
+				Resources: []xpextv1.ComposedTemplate{{
+					Name: pointer.String("bucket"),
+					Base: base(`{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Bucket","spec":{"forProvider":{"region":"us-east-1","grants":[{"id":"a"}]}}}`),
+					Patches: []xpextv1.Patch{
+						{Type: xpextv1.PatchTypePatchSet, PatchSetName: pointer.String("common")},
+						{FromFieldPath: pointer.String("spec.region"), ToFieldPath: pointer.String("spec.forProvider.region")},
+						{FromFieldPath: pointer.String("spec.owner"), ToFieldPath: pointer.String("spec.forProvider.tags[owner]")},
+						{FromFieldPath: pointer.String("spec.grant"), ToFieldPath: pointer.String("spec.forProvider.grants[0].id")},
+						{Type: xpextv1.PatchTypeToCompositeFieldPath, FromFieldPath: pointer.String("status.atProvider.arn"), ToFieldPath: pointer.String("status.arn")},
+					},
+				}},
+			}},
+			want: []Issue{},
+		},
+		"Invalid": {
+			reason: "Unknown base fields, unknown patch paths and unknown kinds should be reported.",
+			comp: &xpextv1.Composition{Spec: xpextv1.CompositionSpec{
+				PatchSets: []xpextv1.PatchSet{{
+					Name:    "common",
+					Patches: []xpextv1.Patch{{FromFieldPath: pointer.String("spec.region"), ToFieldPath: pointer.String("spec.forProvider.regoin")}},
+				}},
+				Resources: []xpextv1.ComposedTemplate{
+					{
+						Name: pointer.String("bucket"),
+						Base: base(`{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Bucket","spec":{"forProvider":{"acl":"private","grants":[{"di":"a"}]}}}`),
+						Patches: []xpextv1.Patch{
+							{Type: xpextv1.PatchTypePatchSet, PatchSetName: pointer.String("common")},
+							{FromFieldPath: pointer.String("spec.grant"), ToFieldPath: pointer.String("spec.forProvider.region[0]")},
+							{Type: xpextv1.PatchTypeToCompositeFieldPath, FromFieldPath: pointer.String("status.atProvider.id"), ToFieldPath: pointer.String("status.id")},
+						},
+					},
+					{
+						Base: base(`{"apiVersion":"s3.aws.upbound.io/v1beta1","kind":"Buckit"}`),
+					},
+				},
+			}},
+			want: []Issue{
+				{Resource: "bucket", Path: "spec.forProvider.acl", Message: "unknown field in base"},
+				{Resource: "bucket", Path: "spec.forProvider.grants[0].di", Message: "unknown field in base"},
+				{Resource: "bucket", Path: "spec.forProvider.regoin", Message: `field "spec.forProvider.regoin" not found in schema`},
+				{Resource: "bucket", Path: "spec.forProvider.region[0]", Message: `field "spec.forProvider.region" is not an array`},
+				{Resource: "bucket", Path: "status.atProvider.id", Message: `field "status.atProvider.id" not found in schema`},
+				{Resource: "1", Message: "no schema found for s3.aws.upbound.io/v1beta1, Buckit"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewValidator(bucketCRD()).Validate(tc.comp)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package comptest runs declarative end-to-end tests of Compositions against
+// a control plane.
+package comptest
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	defaultTimeout = 5 * time.Minute
+
+	errFmtRead      = "unable to read test case %s"
+	errFmtDecode    = "unable to decode test case %s"
+	errFmtNoName    = "test case in %s has no name"
+	errFmtNoClaim   = "test case %s has no claim"
+	errNoTestsFound = "no test cases found"
+)
+
+// A Case is a declarative Composition test. The claim is created in a control
+// plane, and the test passes once the claim reports the expected conditions
+// and its composite resource composes the expected resources.
+type Case struct {
+	// Name of the test case.
+	Name string `json:"name"`
+	// Claim is the claim or composite resource created by the test.
+	Claim map[string]any `json:"claim"`
+	// Timeout is how long to wait for the expectations to be met. Defaults to
+	// five minutes.
+	Timeout *Duration `json:"timeout,omitempty"`
+	// Expect are the expectations of the test.
+	Expect Expectations `json:"expect"`
+
+	// File the test case was read from.
+	File string `json:"-"`
+}
+
+// Expectations of a test case.
+type Expectations struct {
+	// Conditions that must be true on the claim. Defaults to Ready and
+	// Synced.
+	Conditions []string `json:"conditions,omitempty"`
+	// Resources that must be composed.
+	Resources []ExpectedResource `json:"resources,omitempty"`
+}
+
+// An ExpectedResource is a kind of resource the composite resource must
+// compose.
+type ExpectedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Count is the exact number of resources of the kind that must be
+	// composed. If unset at least one must be composed.
+	Count *int `json:"count,omitempty"`
+}
+
+// Duration is a time.Duration that is decoded from a string such as 5m.
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalJSON decodes a duration string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// GetTimeout returns the timeout of the test case.
+func (c *Case) GetTimeout() time.Duration {
+	if c.Timeout == nil {
+		return defaultTimeout
+	}
+	return c.Timeout.Duration
+}
+
+// GetClaim returns the claim of the test case.
+func (c *Case) GetClaim() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: c.Claim}
+}
+
+// Load reads the test cases in the supplied files and directories.
+// Directories are read recursively for .yaml and .yml files, each of which
+// may contain multiple test cases separated by ---.
+func Load(paths ...string) ([]Case, error) {
+	cases := []Case{}
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(f string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(f)) {
+			case ".yaml", ".yml":
+			default:
+				return nil
+			}
+			cs, err := loadFile(f)
+			if err != nil {
+				return err
+			}
+			cases = append(cases, cs...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(cases) == 0 {
+		return nil, errors.New(errNoTestsFound)
+	}
+	return cases, nil
+}
+
+func loadFile(file string) ([]Case, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtRead, file)
+	}
+	defer f.Close() //nolint:errcheck,gosec
+
+	cases := []Case{}
+	d := kyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		c := Case{}
+		if err := d.Decode(&c); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, errors.Wrapf(err, errFmtDecode, file)
+		}
+		// Skip empty documents.
+		if c.Name == "" && c.Claim == nil {
+			continue
+		}
+		if c.Name == "" {
+			return nil, errors.Errorf(errFmtNoName, file)
+		}
+		if c.Claim == nil {
+			return nil, errors.Errorf(errFmtNoClaim, c.Name)
+		}
+		c.File = file
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package comptest
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/pointer"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	content := `
+name: bucket
+claim:
+  apiVersion: example.org/v1alpha1
+  kind: Bucket
+  metadata:
+    name: test
+timeout: 2m
+expect:
+  resources:
+  - apiVersion: s3.aws.upbound.io/v1beta1
+    kind: Bucket
+    count: 1
+---
+name: default-timeout
+claim:
+  apiVersion: example.org/v1alpha1
+  kind: Bucket
+  metadata:
+    name: other
+`
+	if err := os.WriteFile(filepath.Join(dir, "bucket.yaml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a test"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load(...): %v", err)
+	}
+	if diff := cmp.Diff(2, len(cases)); diff != "" {
+		t.Fatalf("Load(...): -want cases, +got cases:\n%s", diff)
+	}
+	if diff := cmp.Diff(2*time.Minute, cases[0].GetTimeout()); diff != "" {
+		t.Errorf("Load(...): -want timeout, +got timeout:\n%s", diff)
+	}
+	if diff := cmp.Diff(defaultTimeout, cases[1].GetTimeout()); diff != "" {
+		t.Errorf("Load(...): -want default timeout, +got timeout:\n%s", diff)
+	}
+	want := []ExpectedResource{{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket", Count: pointer.Int(1)}}
+	if diff := cmp.Diff(want, cases[0].Expect.Resources); diff != "" {
+		t.Errorf("Load(...): -want resources, +got resources:\n%s", diff)
+	}
+}
+
+func TestCheckResources(t *testing.T) {
+	bucket := corev1.ObjectReference{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket", Name: "a"}
+	policy := corev1.ObjectReference{APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "BucketPolicy", Name: "b"}
+
+	type args struct {
+		refs      []corev1.ObjectReference
+		resources []ExpectedResource
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"AtLeastOne": {
+			reason: "A resource without a count should match if at least one is composed.",
+			args: args{
+				refs:      []corev1.ObjectReference{bucket, bucket},
+				resources: []ExpectedResource{{APIVersion: bucket.APIVersion, Kind: bucket.Kind}},
+			},
+		},
+		"Absent": {
+			reason: "A resource without a count should fail if none is composed.",
+			args: args{
+				refs:      []corev1.ObjectReference{bucket},
+				resources: []ExpectedResource{{APIVersion: policy.APIVersion, Kind: policy.Kind}},
+			},
+			want: "expected at least one BucketPolicy.s3.aws.upbound.io/v1beta1, found none",
+		},
+		"WrongCount": {
+			reason: "A resource with a count should fail if a different number is composed.",
+			args: args{
+				refs:      []corev1.ObjectReference{bucket, policy},
+				resources: []ExpectedResource{{APIVersion: bucket.APIVersion, Kind: bucket.Kind, Count: pointer.Int(2)}},
+			},
+			want: "expected 2 Bucket.s3.aws.upbound.io/v1beta1, found 1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := checkResources(tc.args.refs, tc.args.resources)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ncheckResources(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	results := []Result{
+		{Case: Case{Name: "pass", File: "tests/a.yaml"}, Duration: time.Second},
+		{Case: Case{Name: "fail", File: "tests/a.yaml"}, Duration: 2 * time.Second, Failure: "condition Ready is not true"},
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteJUnit(buf, "up test", results); err != nil {
+		t.Fatalf("WriteJUnit(...): %v", err)
+	}
+	for _, want := range []string{
+		`<testsuite name="up test" tests="2" failures="1" time="3.000">`,
+		`<testcase name="pass" classname="tests/a.yaml" time="1.000"></testcase>`,
+		`<failure message="condition Ready is not true"></failure>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("WriteJUnit(...): output does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1alpha1", Kind: "Bucket"}
+	gvr := gvk.GroupVersion().WithResource("buckets")
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+
+	existing := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.org/v1alpha1",
+		"kind":       "Bucket",
+		"metadata":   map[string]any{"name": "test-bucket", "namespace": "default"},
+		"spec":       map[string]any{"region": "eu-central-1"},
+	}}
+	c := Case{
+		Name: "creates-bucket",
+		Claim: map[string]any{
+			"apiVersion": "example.org/v1alpha1",
+			"kind":       "Bucket",
+			"metadata":   map[string]any{"name": "test-bucket"},
+			"spec":       map[string]any{"region": "us-east-1"},
+		},
+		Timeout: &Duration{Duration: time.Millisecond},
+	}
+
+	cases := map[string]struct {
+		reason string
+		keep   bool
+		want   int
+	}{
+		"Delete": {
+			reason: "The claim created by the test should be deleted, and the existing claim with the same name kept unchanged.",
+			want:   1,
+		},
+		"Keep": {
+			reason: "The claim created by the test should be kept with --keep, next to the existing claim with the same name.",
+			keep:   true,
+			want:   2,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				gvr: "BucketList",
+			}, existing.DeepCopy())
+
+			res := NewRunner(client, mapper, WithKeep(tc.keep)).Run(context.Background(), c)
+			if res.Claim == "" || res.Claim == existing.GetName() {
+				t.Errorf("\n%s\nRun(...): want a new claim, got %q", tc.reason, res.Claim)
+			}
+
+			l, err := client.Resource(gvr).Namespace("default").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("List(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, len(l.Items)); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want claims, +got claims:\n%s", tc.reason, diff)
+			}
+			got, err := client.Resource(gvr).Namespace("default").Get(context.Background(), existing.GetName(), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("\n%s\nGet(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(existing.Object["spec"], got.Object["spec"]); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want existing spec, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package comptest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	fieldManager = "up-test"
+	pollInterval = 5 * time.Second
+
+	// suffixLength is the length of the random suffix appended to the name
+	// of the claim of each run.
+	suffixLength = 5
+
+	errFmtMapping        = "unable to find resource for %s"
+	errFmtCreate         = "unable to create claim: %s"
+	errFmtGet            = "unable to get %s %s: %s"
+	errFmtCondition      = "condition %s is not true"
+	errFmtResourceCount  = "expected %d %s, found %d"
+	errFmtResourceAbsent = "expected at least one %s, found none"
+)
+
+// DefaultConditions are the conditions a claim must report as true if a test
+// case does not specify any.
+var DefaultConditions = []string{string(xpv1.TypeReady), string(xpv1.TypeSynced)}
+
+// A Result is the outcome of a test case.
+type Result struct {
+	Case Case
+	// Claim is the name of the claim created by the test case, or empty if
+	// it could not be created.
+	Claim    string
+	Duration time.Duration
+	// Failure describes why the test case failed. It is empty if the test
+	// case passed.
+	Failure string
+}
+
+// Passed returns true if the test case passed.
+func (r Result) Passed() bool {
+	return r.Failure == ""
+}
+
+// A Runner runs test cases against a control plane.
+type Runner struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+	keep   bool
+}
+
+// A RunnerOption configures a Runner.
+type RunnerOption func(*Runner)
+
+// WithKeep keeps the claims created by test cases instead of deleting them
+// once the test case has finished.
+func WithKeep(keep bool) RunnerOption {
+	return func(r *Runner) {
+		r.keep = keep
+	}
+}
+
+// NewRunner returns a Runner that runs test cases using the supplied client.
+func NewRunner(client dynamic.Interface, mapper meta.RESTMapper, opts ...RunnerOption) *Runner {
+	r := &Runner{client: client, mapper: mapper}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// Run runs the supplied test case.
+func (r *Runner) Run(ctx context.Context, c Case) Result {
+	start := time.Now()
+	res := Result{Case: c}
+	res.Claim, res.Failure = r.run(ctx, c)
+	res.Duration = time.Since(start)
+	return res
+}
+
+// run runs the supplied test case and returns the name of the claim it
+// created and why it failed. The claim is created with a random suffix
+// appended to its name, so that each run creates a new claim and never
+// changes or deletes existing objects.
+func (r *Runner) run(ctx context.Context, c Case) (string, string) {
+	claim := c.GetClaim().DeepCopy()
+	ri, err := r.resourceFor(claim)
+	if err != nil {
+		return "", fmt.Sprintf(errFmtCreate, err)
+	}
+	base := claim.GetName()
+	if base == "" {
+		base = fieldManager
+	}
+	claim.SetName(base + "-" + utilrand.String(suffixLength))
+	claim, err = ri.Create(ctx, claim, metav1.CreateOptions{FieldManager: fieldManager})
+	if err != nil {
+		return "", fmt.Sprintf(errFmtCreate, err)
+	}
+	if !r.keep {
+		defer func() {
+			// The claim is deleted even if the test context is cancelled.
+			_ = ri.Delete(context.Background(), claim.GetName(), metav1.DeleteOptions{})
+		}()
+	}
+
+	conditions := c.Expect.Conditions
+	if len(conditions) == 0 {
+		conditions = DefaultConditions
+	}
+	var failure string
+	err = wait.PollUntilContextTimeout(ctx, pollInterval, c.GetTimeout(), true, func(ctx context.Context) (bool, error) {
+		failure = r.check(ctx, ri, claim.GetName(), conditions, c.Expect.Resources)
+		return failure == "", nil
+	})
+	if err != nil && failure == "" {
+		failure = err.Error()
+	}
+	return claim.GetName(), failure
+}
+
+// check returns why the claim does not meet the expectations, or an empty
+// string if it does.
+func (r *Runner) check(ctx context.Context, ri dynamic.ResourceInterface, name string, conditions []string, resources []ExpectedResource) string {
+	claim, err := ri.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Sprintf(errFmtGet, "claim", name, err)
+	}
+	if f := checkConditions(claim, conditions); f != "" {
+		return f
+	}
+	if len(resources) == 0 {
+		return ""
+	}
+
+	// Claims reference their composite resource. Composite resources may
+	// also be tested directly.
+	xr := claim
+	p := fieldpath.Pave(claim.Object)
+	if ref := (corev1.ObjectReference{}); p.GetValueInto("spec.resourceRef", &ref) == nil && ref.Name != "" {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return fmt.Sprintf(errFmtGet, ref.Kind, ref.Name, err)
+		}
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gv.WithKind(ref.Kind))
+		xri, err := r.resourceFor(u)
+		if err != nil {
+			return fmt.Sprintf(errFmtGet, ref.Kind, ref.Name, err)
+		}
+		if xr, err = xri.Get(ctx, ref.Name, metav1.GetOptions{}); err != nil {
+			return fmt.Sprintf(errFmtGet, ref.Kind, ref.Name, err)
+		}
+	}
+	refs := []corev1.ObjectReference{}
+	_ = fieldpath.Pave(xr.Object).GetValueInto("spec.resourceRefs", &refs)
+	return checkResources(refs, resources)
+}
+
+// checkConditions returns the first of the supplied conditions that is not
+// true on the supplied object, or an empty string if all are.
+func checkConditions(u *unstructured.Unstructured, conditions []string) string {
+	conditioned := xpv1.ConditionedStatus{}
+	_ = fieldpath.Pave(u.Object).GetValueInto("status", &conditioned)
+	for _, ct := range conditions {
+		if conditioned.GetCondition(xpv1.ConditionType(ct)).Status != corev1.ConditionTrue {
+			return fmt.Sprintf(errFmtCondition, ct)
+		}
+	}
+	return ""
+}
+
+// checkResources returns why the supplied resource references do not match
+// the expected resources, or an empty string if they do.
+func checkResources(refs []corev1.ObjectReference, resources []ExpectedResource) string {
+	failures := []string{}
+	for _, e := range resources {
+		n := 0
+		for _, ref := range refs {
+			if ref.APIVersion == e.APIVersion && ref.Kind == e.Kind {
+				n++
+			}
+		}
+		kind := e.Kind + "." + e.APIVersion
+		switch {
+		case e.Count != nil && *e.Count != n:
+			failures = append(failures, fmt.Sprintf(errFmtResourceCount, *e.Count, kind, n))
+		case e.Count == nil && n == 0:
+			failures = append(failures, fmt.Sprintf(errFmtResourceAbsent, kind))
+		}
+	}
+	return strings.Join(failures, "; ")
+}
+
+func (r *Runner) resourceFor(u *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := u.GroupVersionKind()
+	m, err := r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtMapping, gvk)
+	}
+	if m.Scope.Name() == meta.RESTScopeNameNamespace {
+		ns := u.GetNamespace()
+		if ns == "" {
+			ns = metav1.NamespaceDefault
+			u.SetNamespace(ns)
+		}
+		return r.client.Resource(m.Resource).Namespace(ns), nil
+	}
+	return r.client.Resource(m.Resource), nil
+}
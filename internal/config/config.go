@@ -37,6 +37,11 @@ const (
 
 	errProfileNotFoundFmt = "profile not found with identifier: %s"
 	errNoProfilesFound    = "no profiles found"
+
+	errRegistryNotFoundFmt = "no credentials found for registry: %s"
+
+	errBookmarkNotFoundFmt = "bookmark not found with name: %s"
+	errInvalidBookmark     = "bookmark must reference a profile"
 )
 
 // QuietFlag provides a named boolean type for the QuietFlag.
@@ -82,6 +87,29 @@ type Upbound struct {
 	// Profiles contain sets of credentials for communicating with Upbound. Key
 	// is name of the profile.
 	Profiles map[string]Profile `json:"profiles,omitempty"`
+
+	// Bookmarks are named contexts that can be switched to with a single
+	// command. Key is the name of the bookmark.
+	Bookmarks map[string]Bookmark `json:"bookmarks,omitempty"`
+}
+
+// A Bookmark is a named combination of a profile, Space, group and control
+// plane.
+type Bookmark struct {
+	// Profile is the profile used when the bookmark is selected.
+	Profile string `json:"profile"`
+
+	// Account is the account used when the bookmark is selected.
+	Account string `json:"account,omitempty"`
+
+	// Space is the kubeconfig context of the Space.
+	Space string `json:"space,omitempty"`
+
+	// Group is the control plane group, i.e. the namespace in the Space.
+	Group string `json:"group,omitempty"`
+
+	// ControlPlane is the name of the control plane.
+	ControlPlane string `json:"controlPlane,omitempty"`
 }
 
 // ProfileType is a type of Upbound profile.
@@ -112,6 +140,41 @@ type Profile struct {
 	// * flags
 	// * environment variables
 	BaseConfig map[string]string `json:"base,omitempty"`
+
+	// Registries contains credentials for OCI registries other than the
+	// Upbound registry. Key is the hostname of the registry.
+	Registries map[string]RegistryCredentials `json:"registries,omitempty"`
+}
+
+// RegistryCredentials are the credentials used to authenticate to an OCI
+// registry.
+type RegistryCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// A ProfileBundle is a set of profiles exported for distribution to other
+// users. Key is the name of the profile.
+type ProfileBundle struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// WithoutSecrets returns a copy of the profile that contains only settings
+// that are safe to share, i.e. the profile type, account and base config.
+// The ID, session and registry credentials are removed.
+func (p Profile) WithoutSecrets() Profile {
+	base := make(map[string]string, len(p.BaseConfig))
+	for k, v := range p.BaseConfig {
+		base[k] = v
+	}
+	if len(base) == 0 {
+		base = nil
+	}
+	return Profile{
+		Type:       p.Type,
+		Account:    p.Account,
+		BaseConfig: base,
+	}
 }
 
 // RedactedProfile embeds a Upbound Profile for the sole purpose of redacting
@@ -132,6 +195,15 @@ func (p RedactedProfile) MarshalJSON() ([]byte, error) {
 		s = "REDACTED"
 	}
 	pc.Session = s
+	if len(pc.Registries) > 0 {
+		// Copy the map so that the original credentials are not modified.
+		regs := make(map[string]RegistryCredentials, len(pc.Registries))
+		for k, v := range pc.Registries {
+			v.Password = "REDACTED"
+			regs[k] = v
+		}
+		pc.Registries = regs
+	}
 	return json.Marshal(&pc)
 }
 
@@ -155,6 +227,42 @@ func (c *Config) AddOrUpdateUpboundProfile(name string, new Profile) error {
 	return nil
 }
 
+// ImportUpboundProfile imports the supplied profile into the Config. If a
+// profile of the same name exists, its ID, session and registry credentials
+// are retained unless the imported profile supplies them, and the base config
+// of the imported profile is merged into the existing one.
+func (c *Config) ImportUpboundProfile(name string, p Profile) {
+	if c.Upbound.Profiles == nil {
+		c.Upbound.Profiles = map[string]Profile{}
+	}
+	cur, ok := c.Upbound.Profiles[name]
+	if !ok {
+		c.Upbound.Profiles[name] = p
+		return
+	}
+	if p.ID == "" {
+		p.ID, p.Type, p.Session = cur.ID, cur.Type, cur.Session
+	}
+	if len(p.Registries) == 0 {
+		p.Registries = cur.Registries
+	}
+	if p.Account == "" {
+		p.Account = cur.Account
+	}
+	base := make(map[string]string, len(cur.BaseConfig)+len(p.BaseConfig))
+	for k, v := range cur.BaseConfig {
+		base[k] = v
+	}
+	for k, v := range p.BaseConfig {
+		base[k] = v
+	}
+	if len(base) == 0 {
+		base = nil
+	}
+	p.BaseConfig = base
+	c.Upbound.Profiles[name] = p
+}
+
 // GetDefaultUpboundProfile gets the default Upbound profile or returns an error if
 // default is not set or default profile does not exist.
 func (c *Config) GetDefaultUpboundProfile() (string, Profile, error) {
@@ -250,6 +358,78 @@ func (c *Config) RemoveFromBaseConfig(name, key string) error {
 	return nil
 }
 
+// SetRegistryCredentials stores the supplied credentials for the registry on
+// the Profile that corresponds to the given name. If the supplied name does not
+// match an existing Profile an error is returned.
+func (c *Config) SetRegistryCredentials(name, registry string, creds RegistryCredentials) error {
+	profile, ok := c.Upbound.Profiles[name]
+	if !ok {
+		return errors.Errorf(errProfileNotFoundFmt, name)
+	}
+
+	if profile.Registries == nil {
+		profile.Registries = make(map[string]RegistryCredentials)
+	}
+
+	profile.Registries[registry] = creds
+	c.Upbound.Profiles[name] = profile
+	return nil
+}
+
+// RemoveRegistryCredentials removes the credentials for the registry from the
+// Profile that corresponds to the given name. If the supplied name does not
+// match an existing Profile or no credentials are stored for the registry an
+// error is returned.
+func (c *Config) RemoveRegistryCredentials(name, registry string) error {
+	profile, ok := c.Upbound.Profiles[name]
+	if !ok {
+		return errors.Errorf(errProfileNotFoundFmt, name)
+	}
+
+	if _, ok := profile.Registries[registry]; !ok {
+		return errors.Errorf(errRegistryNotFoundFmt, registry)
+	}
+
+	delete(profile.Registries, registry)
+	c.Upbound.Profiles[name] = profile
+	return nil
+}
+
+// AddOrUpdateBookmark adds or updates a bookmark in the Config.
+func (c *Config) AddOrUpdateBookmark(name string, b Bookmark) error {
+	if b.Profile == "" {
+		return errors.New(errInvalidBookmark)
+	}
+	if _, ok := c.Upbound.Profiles[b.Profile]; !ok {
+		return errors.Errorf(errProfileNotFoundFmt, b.Profile)
+	}
+	if c.Upbound.Bookmarks == nil {
+		c.Upbound.Bookmarks = map[string]Bookmark{}
+	}
+	c.Upbound.Bookmarks[name] = b
+	return nil
+}
+
+// GetBookmark gets the bookmark with the given name. If no bookmark exists
+// with the given name an error is returned.
+func (c *Config) GetBookmark(name string) (Bookmark, error) {
+	b, ok := c.Upbound.Bookmarks[name]
+	if !ok {
+		return Bookmark{}, errors.Errorf(errBookmarkNotFoundFmt, name)
+	}
+	return b, nil
+}
+
+// RemoveBookmark removes the bookmark with the given name. If no bookmark
+// exists with the given name an error is returned.
+func (c *Config) RemoveBookmark(name string) error {
+	if _, ok := c.Upbound.Bookmarks[name]; !ok {
+		return errors.Errorf(errBookmarkNotFoundFmt, name)
+	}
+	delete(c.Upbound.Bookmarks, name)
+	return nil
+}
+
 // BaseToJSON converts the base config of the given Profile to JSON. If the
 // config couldn't be converted or if the supplied name does not correspond
 // to an existing Profile, an error is returned.
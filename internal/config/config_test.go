@@ -516,3 +516,277 @@ func TestBaseToJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestSetRegistryCredentials(t *testing.T) {
+	name := "cool-user"
+	creds := RegistryCredentials{Username: "user", Password: "pass"}
+
+	type args struct {
+		profile  string
+		registry string
+		cfg      *Config
+	}
+	type want struct {
+		err        error
+		registries map[string]RegistryCredentials
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorNoProfilesExist": {
+			reason: "If the profile does not exist an error should be returned.",
+			args: args{
+				profile:  name,
+				registry: "ghcr.io",
+				cfg:      &Config{},
+			},
+			want: want{
+				err: errors.Errorf(errProfileNotFoundFmt, name),
+			},
+		},
+		"Successful": {
+			reason: "If the profile exists, the credentials should be stored for the registry.",
+			args: args{
+				profile:  name,
+				registry: "ghcr.io",
+				cfg: &Config{
+					Upbound: Upbound{
+						Profiles: map[string]Profile{
+							name: {Type: UserProfileType},
+						},
+					},
+				},
+			},
+			want: want{
+				registries: map[string]RegistryCredentials{
+					"ghcr.io": creds,
+				},
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.args.cfg.SetRegistryCredentials(tc.args.profile, tc.args.registry, creds)
+			p, _ := tc.args.cfg.GetUpboundProfile(tc.args.profile)
+
+			if diff := cmp.Diff(tc.want.registries, p.Registries); diff != "" {
+				t.Errorf("\n%s\nSetRegistryCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSetRegistryCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRemoveRegistryCredentials(t *testing.T) {
+	name := "cool-user"
+
+	type args struct {
+		profile  string
+		registry string
+		cfg      *Config
+	}
+	type want struct {
+		err        error
+		registries map[string]RegistryCredentials
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorNoCredentials": {
+			reason: "If no credentials exist for the registry an error should be returned.",
+			args: args{
+				profile:  name,
+				registry: "ghcr.io",
+				cfg: &Config{
+					Upbound: Upbound{
+						Profiles: map[string]Profile{
+							name: {Type: UserProfileType},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errRegistryNotFoundFmt, "ghcr.io"),
+			},
+		},
+		"Successful": {
+			reason: "If credentials exist for the registry they should be removed.",
+			args: args{
+				profile:  name,
+				registry: "ghcr.io",
+				cfg: &Config{
+					Upbound: Upbound{
+						Profiles: map[string]Profile{
+							name: {
+								Type: UserProfileType,
+								Registries: map[string]RegistryCredentials{
+									"ghcr.io": {Username: "user", Password: "pass"},
+									"quay.io": {Username: "user", Password: "pass"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				registries: map[string]RegistryCredentials{
+					"quay.io": {Username: "user", Password: "pass"},
+				},
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			err := tc.args.cfg.RemoveRegistryCredentials(tc.args.profile, tc.args.registry)
+			p, _ := tc.args.cfg.GetUpboundProfile(tc.args.profile)
+
+			if diff := cmp.Diff(tc.want.registries, p.Registries); diff != "" {
+				t.Errorf("\n%s\nRemoveRegistryCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRemoveRegistryCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithoutSecrets(t *testing.T) {
+	p := Profile{
+		ID:         "cool-token",
+		Type:       TokenProfileType,
+		Session:    "cool-session",
+		Account:    "cool-org",
+		BaseConfig: map[string]string{"domain": "https://upbound.example.com"},
+		Registries: map[string]RegistryCredentials{
+			"ghcr.io": {Username: "user", Password: "pass"},
+		},
+	}
+	want := Profile{
+		Type:       TokenProfileType,
+		Account:    "cool-org",
+		BaseConfig: map[string]string{"domain": "https://upbound.example.com"},
+	}
+	if diff := cmp.Diff(want, p.WithoutSecrets()); diff != "" {
+		t.Errorf("\nWithoutSecrets(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestImportUpboundProfile(t *testing.T) {
+	name := "cool-profile"
+
+	type args struct {
+		profile Profile
+		cfg     *Config
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   Profile
+	}{
+		"NewProfile": {
+			reason: "A profile that does not exist should be added as is.",
+			args: args{
+				profile: Profile{Type: UserProfileType, Account: "cool-org"},
+				cfg:     &Config{},
+			},
+			want: Profile{Type: UserProfileType, Account: "cool-org"},
+		},
+		"MergeWithoutSecrets": {
+			reason: "Importing a profile without secrets should retain the credentials of the existing profile and merge base config.",
+			args: args{
+				profile: Profile{
+					Type:       UserProfileType,
+					Account:    "new-org",
+					BaseConfig: map[string]string{"domain": "https://new.example.com"},
+				},
+				cfg: &Config{
+					Upbound: Upbound{
+						Profiles: map[string]Profile{
+							name: {
+								ID:         "cool-user",
+								Type:       UserProfileType,
+								Session:    "cool-session",
+								Account:    "old-org",
+								BaseConfig: map[string]string{"domain": "https://old.example.com", "insecure-skip-tls-verify": "true"},
+								Registries: map[string]RegistryCredentials{"ghcr.io": {Username: "user", Password: "pass"}},
+							},
+						},
+					},
+				},
+			},
+			want: Profile{
+				ID:         "cool-user",
+				Type:       UserProfileType,
+				Session:    "cool-session",
+				Account:    "new-org",
+				BaseConfig: map[string]string{"domain": "https://new.example.com", "insecure-skip-tls-verify": "true"},
+				Registries: map[string]RegistryCredentials{"ghcr.io": {Username: "user", Password: "pass"}},
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			tc.args.cfg.ImportUpboundProfile(name, tc.args.profile)
+			p, _ := tc.args.cfg.GetUpboundProfile(name)
+
+			if diff := cmp.Diff(tc.want, p); diff != "" {
+				t.Errorf("\n%s\nImportUpboundProfile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAddOrUpdateBookmark(t *testing.T) {
+	cfg := &Config{
+		Upbound: Upbound{
+			Profiles: map[string]Profile{
+				"cool-profile": {ID: "cool-user", Type: UserProfileType},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		bookmark Bookmark
+		want     error
+	}{
+		"ErrorNoProfile": {
+			reason:   "A bookmark must reference a profile.",
+			bookmark: Bookmark{Group: "team-a"},
+			want:     errors.New(errInvalidBookmark),
+		},
+		"ErrorProfileNotFound": {
+			reason:   "A bookmark must reference an existing profile.",
+			bookmark: Bookmark{Profile: "other-profile"},
+			want:     errors.Errorf(errProfileNotFoundFmt, "other-profile"),
+		},
+		"Successful": {
+			reason:   "A bookmark referencing an existing profile should be added.",
+			bookmark: Bookmark{Profile: "cool-profile", Space: "kind-spaces", Group: "team-a", ControlPlane: "ctp1"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := cfg.AddOrUpdateBookmark("prod-team-a", tc.bookmark)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nAddOrUpdateBookmark(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			got, _ := cfg.GetBookmark("prod-team-a")
+			if diff := cmp.Diff(tc.bookmark, got); diff != "" {
+				t.Errorf("\n%s\nGetBookmark(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package state summarizes the Crossplane state of a control plane, so that
+// the state of control planes can be compared.
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// CategoryPackage is the category of installed packages.
+	CategoryPackage = "Package"
+	// CategoryXRD is the category of CompositeResourceDefinitions.
+	CategoryXRD = "XRD"
+	// CategoryComposition is the category of Compositions, identified by
+	// the hash of their latest revision.
+	CategoryComposition = "Composition"
+	// CategoryClaim is the category of claim counts per claim type.
+	CategoryClaim = "Claims"
+
+	compositionNameLabel = "crossplane.io/composition-name"
+	compositionHashLabel = "crossplane.io/composition-hash"
+
+	// shortHashLength is the number of characters of a composition hash
+	// reported as a change.
+	shortHashLength = 12
+
+	errFmtList = "unable to list %s"
+)
+
+var (
+	xrdGVR = schema.GroupVersionResource{Group: "apiextensions.crossplane.io", Version: "v1", Resource: "compositeresourcedefinitions"}
+	revGVR = schema.GroupVersionResource{Group: "apiextensions.crossplane.io", Version: "v1", Resource: "compositionrevisions"}
+
+	packageGVRs = []schema.GroupVersionResource{
+		{Group: "pkg.crossplane.io", Version: "v1", Resource: "providers"},
+		{Group: "pkg.crossplane.io", Version: "v1", Resource: "configurations"},
+		{Group: "pkg.crossplane.io", Version: "v1beta1", Resource: "functions"},
+	}
+)
+
+// State is a summary of the Crossplane state of a control plane. Each map is
+// keyed by the name of an object.
+type State struct {
+	// Packages are the package references of the installed packages, keyed
+	// by <resource>/<name>.
+	Packages map[string]string
+	// XRDs are the referenceable versions of the CompositeResourceDefinitions.
+	XRDs map[string]string
+	// Compositions are the hashes of the latest revisions of the
+	// Compositions. Revision numbers are not compared as they are counted
+	// separately in each control plane.
+	Compositions map[string]string
+	// Claims are the number of claims per claim type, keyed by
+	// <kind>.<group>.
+	Claims map[string]int
+}
+
+// Collect reads the state of the control plane served by the supplied client.
+func Collect(ctx context.Context, client dynamic.Interface) (*State, error) { //nolint:gocyclo
+	s := &State{
+		Packages:     map[string]string{},
+		XRDs:         map[string]string{},
+		Compositions: map[string]string{},
+		Claims:       map[string]int{},
+	}
+
+	for _, gvr := range packageGVRs {
+		l, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if kerrors.IsNotFound(err) {
+			// The package type is not served by this version of Crossplane.
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtList, gvr.Resource)
+		}
+		for _, u := range l.Items {
+			ref, _ := fieldpath.Pave(u.Object).GetString("spec.package")
+			s.Packages[gvr.Resource+"/"+u.GetName()] = ref
+		}
+	}
+
+	xrds, err := client.Resource(xrdGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtList, xrdGVR.Resource)
+	}
+	for i := range xrds.Items {
+		xrd := &xrds.Items[i]
+		version := referenceableVersion(xrd)
+		s.XRDs[xrd.GetName()] = version
+
+		p := fieldpath.Pave(xrd.Object)
+		plural, _ := p.GetString("spec.claimNames.plural")
+		if plural == "" || version == "" {
+			continue
+		}
+		group, _ := p.GetString("spec.group")
+		kind, _ := p.GetString("spec.claimNames.kind")
+		claims, err := client.Resource(schema.GroupVersionResource{Group: group, Version: version, Resource: plural}).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtList, plural+"."+group)
+		}
+		s.Claims[kind+"."+group] = len(claims.Items)
+	}
+
+	revs, err := client.Resource(revGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtList, revGVR.Resource)
+	}
+	latest := map[string]int64{}
+	for i := range revs.Items {
+		u := &revs.Items[i]
+		name := u.GetLabels()[compositionNameLabel]
+		if name == "" {
+			continue
+		}
+		rev, _ := fieldpath.Pave(u.Object).GetInteger("spec.revision")
+		if _, ok := latest[name]; ok && rev <= latest[name] {
+			continue
+		}
+		latest[name] = rev
+		s.Compositions[name] = compositionHash(u)
+	}
+	return s, nil
+}
+
+// compositionHash returns the hash of the Composition a CompositionRevision
+// was created from. Revisions are labelled with it by Crossplane. If the label
+// is missing, the spec of the revision, excluding the revision number, is
+// hashed instead.
+func compositionHash(rev *unstructured.Unstructured) string {
+	if h := rev.GetLabels()[compositionHashLabel]; h != "" {
+		return h
+	}
+	spec, _, _ := unstructured.NestedMap(rev.Object, "spec")
+	delete(spec, "revision")
+	b, _ := json.Marshal(spec)
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// referenceableVersion returns the version of an XRD that Compositions
+// reference, or an empty string if there is none.
+func referenceableVersion(xrd *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(xrd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if ref, _ := m["referenceable"].(bool); ref {
+			name, _ := m["name"].(string)
+			return name
+		}
+	}
+	return ""
+}
+
+// A Change is a difference between the state of two control planes. A and B
+// are empty if the object does not exist in the respective control plane.
+type Change struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	A        string `json:"a,omitempty"`
+	B        string `json:"b,omitempty"`
+}
+
+// Diff returns the differences between two states, sorted by category and
+// name.
+func Diff(a, b *State) []Change {
+	changes := []Change{}
+	changes = append(changes, diff(CategoryPackage, a.Packages, b.Packages, identity)...)
+	changes = append(changes, diff(CategoryXRD, a.XRDs, b.XRDs, identity)...)
+	changes = append(changes, diff(CategoryComposition, a.Compositions, b.Compositions, func(h string) string {
+		if len(h) > shortHashLength {
+			h = h[:shortHashLength]
+		}
+		return "hash " + h
+	})...)
+	changes = append(changes, diff(CategoryClaim, a.Claims, b.Claims, strconv.Itoa)...)
+	return changes
+}
+
+func identity(s string) string {
+	return s
+}
+
+func diff[T comparable](category string, a, b map[string]T, format func(T) string) []Change {
+	names := map[string]bool{}
+	for n := range a {
+		names[n] = true
+	}
+	for n := range b {
+		names[n] = true
+	}
+	changes := []Change{}
+	for n := range names {
+		va, inA := a[n]
+		vb, inB := b[n]
+		if inA && inB && va == vb {
+			continue
+		}
+		c := Change{Category: category, Name: n}
+		if inA {
+			c.A = format(va)
+		}
+		if inB {
+			c.B = format(vb)
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
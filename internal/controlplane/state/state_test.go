@@ -0,0 +1,121 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiff(t *testing.T) {
+	type args struct {
+		a *State
+		b *State
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []Change
+	}{
+		"Identical": {
+			reason: "Identical states should not have any changes.",
+			args: args{
+				a: &State{Packages: map[string]string{"providers/aws": "xpkg.upbound.io/upbound/provider-aws:v0.40.0"}},
+				b: &State{Packages: map[string]string{"providers/aws": "xpkg.upbound.io/upbound/provider-aws:v0.40.0"}},
+			},
+			want: []Change{},
+		},
+		"SameCompositionDifferentRevision": {
+			reason: "Compositions with the same hash should not be reported, whatever their revision number.",
+			args: args{
+				a: &State{Compositions: map[string]string{"cluster": "4f7d3a1c9e2b8d6f0a5c"}},
+				b: &State{Compositions: map[string]string{"cluster": "4f7d3a1c9e2b8d6f0a5c"}},
+			},
+			want: []Change{},
+		},
+		"Changed": {
+			reason: "Objects that differ or exist on only one side should be reported in category order.",
+			args: args{
+				a: &State{
+					Packages:     map[string]string{"providers/aws": "provider-aws:v0.40.0", "providers/gcp": "provider-gcp:v0.35.0"},
+					XRDs:         map[string]string{"xclusters.example.org": "v1alpha1"},
+					Compositions: map[string]string{"cluster": "4f7d3a1c9e2b8d6f0a5c"},
+					Claims:       map[string]int{"Cluster.example.org": 4},
+				},
+				b: &State{
+					Packages:     map[string]string{"providers/aws": "provider-aws:v0.41.0"},
+					XRDs:         map[string]string{"xclusters.example.org": "v1alpha1"},
+					Compositions: map[string]string{"cluster": "9b1e6c2f7a3d5e8c4b0f"},
+					Claims:       map[string]int{"Cluster.example.org": 4, "Database.example.org": 1},
+				},
+			},
+			want: []Change{
+				{Category: CategoryPackage, Name: "providers/aws", A: "provider-aws:v0.40.0", B: "provider-aws:v0.41.0"},
+				{Category: CategoryPackage, Name: "providers/gcp", A: "provider-gcp:v0.35.0"},
+				{Category: CategoryComposition, Name: "cluster", A: "hash 4f7d3a1c9e2b", B: "hash 9b1e6c2f7a3d"},
+				{Category: CategoryClaim, Name: "Database.example.org", B: "1"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Diff(tc.args.a, tc.args.b)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompositionHash(t *testing.T) {
+	rev := func(labels map[string]any, revision int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"labels": labels},
+			"spec": map[string]any{
+				"revision":                          revision,
+				"compositeTypeRef":                  map[string]any{"apiVersion": "example.org/v1alpha1", "kind": "XCluster"},
+				"writeConnectionSecretsToNamespace": "crossplane-system",
+			},
+		}}
+	}
+	cases := map[string]struct {
+		reason string
+		a      *unstructured.Unstructured
+		b      *unstructured.Unstructured
+		same   bool
+	}{
+		"Label": {
+			reason: "The hash label set by Crossplane should be used.",
+			a:      rev(map[string]any{compositionHashLabel: "4f7d3a1c9e2b"}, 3),
+			b:      rev(map[string]any{compositionHashLabel: "9b1e6c2f7a3d"}, 3),
+			same:   false,
+		},
+		"RevisionNumber": {
+			reason: "Revisions of the same spec should have the same hash, whatever their revision number.",
+			a:      rev(nil, 3),
+			b:      rev(nil, 2),
+			same:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.same, compositionHash(tc.a) == compositionHash(tc.b)); diff != "" {
+				t.Errorf("\n%s\ncompositionHash(...): -want same, +got same:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
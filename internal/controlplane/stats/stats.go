@@ -0,0 +1,294 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats summarizes the objects of a control plane, e.g. to size a
+// target control plane before a migration.
+package stats
+
+import (
+	"context"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// DefaultPageSize is the default number of objects listed per request.
+	DefaultPageSize = 500
+
+	categoryClaim     = "claim"
+	categoryComposite = "composite"
+	categoryManaged   = "managed"
+
+	packageLabel = "pkg.crossplane.io/package"
+
+	errFmtList = "unable to list %s"
+)
+
+var (
+	crdGVR         = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	providerRevGVR = schema.GroupVersionResource{Group: "pkg.crossplane.io", Version: "v1", Resource: "providerrevisions"}
+
+	compositionRefPaths = []string{"spec.compositionRef.name", "spec.crossplane.compositionRef.name"}
+)
+
+// Stats summarizes the custom resources of a control plane.
+type Stats struct {
+	Kinds        []KindCount      `json:"kinds"`
+	Providers    []ProviderCount  `json:"providers"`
+	Claims       []NamespaceCount `json:"claims"`
+	Compositions []NameCount      `json:"compositions"`
+}
+
+// KindCount is the number of objects of a custom resource kind.
+type KindCount struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Kind     string `json:"kind"`
+	Category string `json:"category,omitempty"`
+	Count    int    `json:"count"`
+}
+
+// ProviderCount is the number of managed resources of a provider.
+type ProviderCount struct {
+	Provider string `json:"provider"`
+	Kinds    int    `json:"kinds"`
+	Count    int    `json:"count"`
+}
+
+// NamespaceCount is the number of claims in a namespace.
+type NamespaceCount struct {
+	Namespace string `json:"namespace"`
+	Count     int    `json:"count"`
+}
+
+// NameCount is the number of composite resources using a Composition.
+type NameCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Collector collects the stats of a control plane.
+type Collector struct {
+	client   dynamic.Interface
+	pageSize int64
+}
+
+// Option modifies a Collector.
+type Option func(*Collector)
+
+// WithPageSize sets the number of objects listed per request.
+func WithPageSize(n int64) Option {
+	return func(c *Collector) {
+		c.pageSize = n
+	}
+}
+
+// NewCollector constructs a Collector. Requests are rate limited by the
+// supplied client.
+func NewCollector(client dynamic.Interface, opts ...Option) *Collector {
+	c := &Collector{client: client, pageSize: DefaultPageSize}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Collect lists the objects of every custom resource kind served by the
+// control plane, and summarizes them per kind, per provider, per claim
+// namespace and per Composition.
+func (c *Collector) Collect(ctx context.Context) (*Stats, error) { //nolint:gocyclo
+	crds, err := c.list(ctx, crdGVR)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := c.revisionProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stats{Kinds: []KindCount{}}
+	perProvider := map[string]*ProviderCount{}
+	claims := map[string]int{}
+	compositions := map[string]int{}
+	for i := range crds {
+		crd := &crds[i]
+		p := fieldpath.Pave(crd.Object)
+		group, _ := p.GetString("spec.group")
+		kind, _ := p.GetString("spec.names.kind")
+		plural, _ := p.GetString("spec.names.plural")
+		version := storageVersion(crd)
+		if version == "" {
+			continue
+		}
+		category := crossplaneCategory(crd)
+
+		objs, err := c.list(ctx, schema.GroupVersionResource{Group: group, Version: version, Resource: plural})
+		if err != nil {
+			return nil, err
+		}
+		s.Kinds = append(s.Kinds, KindCount{Group: group, Version: version, Kind: kind, Category: category, Count: len(objs)})
+
+		switch category {
+		case categoryManaged:
+			name := providerOf(crd, providers)
+			pc, ok := perProvider[name]
+			if !ok {
+				pc = &ProviderCount{Provider: name}
+				perProvider[name] = pc
+			}
+			pc.Kinds++
+			pc.Count += len(objs)
+		case categoryClaim:
+			for _, o := range objs {
+				claims[o.GetNamespace()]++
+			}
+		case categoryComposite:
+			for _, o := range objs {
+				if name := compositionOf(&o); name != "" {
+					compositions[name]++
+				}
+			}
+		}
+	}
+
+	sort.Slice(s.Kinds, func(i, j int) bool {
+		if s.Kinds[i].Count != s.Kinds[j].Count {
+			return s.Kinds[i].Count > s.Kinds[j].Count
+		}
+		if s.Kinds[i].Group != s.Kinds[j].Group {
+			return s.Kinds[i].Group < s.Kinds[j].Group
+		}
+		return s.Kinds[i].Kind < s.Kinds[j].Kind
+	})
+	s.Providers = make([]ProviderCount, 0, len(perProvider))
+	for _, pc := range perProvider {
+		s.Providers = append(s.Providers, *pc)
+	}
+	sort.Slice(s.Providers, func(i, j int) bool {
+		if s.Providers[i].Count != s.Providers[j].Count {
+			return s.Providers[i].Count > s.Providers[j].Count
+		}
+		return s.Providers[i].Provider < s.Providers[j].Provider
+	})
+	s.Claims = make([]NamespaceCount, 0, len(claims))
+	for ns, n := range claims {
+		s.Claims = append(s.Claims, NamespaceCount{Namespace: ns, Count: n})
+	}
+	sort.Slice(s.Claims, func(i, j int) bool {
+		if s.Claims[i].Count != s.Claims[j].Count {
+			return s.Claims[i].Count > s.Claims[j].Count
+		}
+		return s.Claims[i].Namespace < s.Claims[j].Namespace
+	})
+	s.Compositions = make([]NameCount, 0, len(compositions))
+	for name, n := range compositions {
+		s.Compositions = append(s.Compositions, NameCount{Name: name, Count: n})
+	}
+	sort.Slice(s.Compositions, func(i, j int) bool {
+		if s.Compositions[i].Count != s.Compositions[j].Count {
+			return s.Compositions[i].Count > s.Compositions[j].Count
+		}
+		return s.Compositions[i].Name < s.Compositions[j].Name
+	})
+	return s, nil
+}
+
+// list lists all objects of a resource, one page at a time.
+func (c *Collector) list(ctx context.Context, gvr schema.GroupVersionResource) ([]unstructured.Unstructured, error) {
+	objs := []unstructured.Unstructured{}
+	opts := metav1.ListOptions{Limit: c.pageSize}
+	for {
+		l, err := c.client.Resource(gvr).List(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtList, gvr.GroupResource())
+		}
+		objs = append(objs, l.Items...)
+		if l.GetContinue() == "" {
+			return objs, nil
+		}
+		opts.Continue = l.GetContinue()
+	}
+}
+
+// revisionProviders returns the providers of provider revisions, keyed by the
+// name of the revision.
+func (c *Collector) revisionProviders(ctx context.Context) (map[string]string, error) {
+	revs, err := c.list(ctx, providerRevGVR)
+	if err != nil {
+		return nil, err
+	}
+	providers := make(map[string]string, len(revs))
+	for _, r := range revs {
+		providers[r.GetName()] = r.GetLabels()[packageLabel]
+	}
+	return providers, nil
+}
+
+// providerOf returns the provider that owns a CRD, or an empty string if it
+// is not owned by a provider.
+func providerOf(crd *unstructured.Unstructured, providers map[string]string) string {
+	for _, ref := range crd.GetOwnerReferences() {
+		if ref.Kind != "ProviderRevision" {
+			continue
+		}
+		if p := providers[ref.Name]; p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
+func compositionOf(xr *unstructured.Unstructured) string {
+	p := fieldpath.Pave(xr.Object)
+	for _, path := range compositionRefPaths {
+		if name, _ := p.GetString(path); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// crossplaneCategory returns whether a CRD defines claims, composite or
+// managed resources.
+func crossplaneCategory(crd *unstructured.Unstructured) string {
+	categories, _, _ := unstructured.NestedStringSlice(crd.Object, "spec", "names", "categories")
+	for _, c := range categories {
+		switch c {
+		case categoryClaim, categoryComposite, categoryManaged:
+			return c
+		}
+	}
+	return ""
+}
+
+func storageVersion(crd *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if storage, _ := m["storage"].(bool); storage {
+			name, _ := m["name"].(string)
+			return name
+		}
+	}
+	return ""
+}
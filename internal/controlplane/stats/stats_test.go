@@ -0,0 +1,104 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func crd(group, kind, plural, category, owner string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": plural + "." + group},
+		"spec": map[string]any{
+			"group": group,
+			"names": map[string]any{"kind": kind, "plural": plural, "categories": []any{"crossplane", category}},
+			"versions": []any{
+				map[string]any{"name": "v1alpha1", "storage": false},
+				map[string]any{"name": "v1", "storage": true},
+			},
+		},
+	}}
+	if owner != "" {
+		u.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "pkg.crossplane.io/v1", Kind: "ProviderRevision", Name: owner}})
+	}
+	return u
+}
+
+func object(group, kind, namespace, name string, fields map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": fields}}
+	u.SetAPIVersion(group + "/v1")
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func TestCollect(t *testing.T) {
+	rev := &unstructured.Unstructured{}
+	rev.SetAPIVersion("pkg.crossplane.io/v1")
+	rev.SetKind("ProviderRevision")
+	rev.SetName("provider-aws-s3-abc")
+	rev.SetLabels(map[string]string{packageLabel: "provider-aws-s3"})
+
+	objs := []runtime.Object{
+		rev,
+		crd("s3.aws.upbound.io", "Bucket", "buckets", categoryManaged, "provider-aws-s3-abc"),
+		crd("example.org", "XBucket", "xbuckets", categoryComposite, ""),
+		crd("example.org", "BucketClaim", "bucketclaims", categoryClaim, ""),
+		object("s3.aws.upbound.io", "Bucket", "", "a", nil),
+		object("s3.aws.upbound.io", "Bucket", "", "b", nil),
+		object("s3.aws.upbound.io", "Bucket", "", "c", nil),
+		object("example.org", "XBucket", "", "x1", map[string]any{"compositionRef": map[string]any{"name": "bucket"}}),
+		object("example.org", "XBucket", "", "x2", map[string]any{"crossplane": map[string]any{"compositionRef": map[string]any{"name": "bucket"}}}),
+		object("example.org", "BucketClaim", "team-a", "c1", nil),
+		object("example.org", "BucketClaim", "team-a", "c2", nil),
+		object("example.org", "BucketClaim", "team-b", "c3", nil),
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		crdGVR:         "CustomResourceDefinitionList",
+		providerRevGVR: "ProviderRevisionList",
+		{Group: "s3.aws.upbound.io", Version: "v1", Resource: "buckets"}: "BucketList",
+		{Group: "example.org", Version: "v1", Resource: "xbuckets"}:      "XBucketList",
+		{Group: "example.org", Version: "v1", Resource: "bucketclaims"}:  "BucketClaimList",
+	}, objs...)
+
+	want := &Stats{
+		Kinds: []KindCount{
+			{Group: "example.org", Version: "v1", Kind: "BucketClaim", Category: categoryClaim, Count: 3},
+			{Group: "s3.aws.upbound.io", Version: "v1", Kind: "Bucket", Category: categoryManaged, Count: 3},
+			{Group: "example.org", Version: "v1", Kind: "XBucket", Category: categoryComposite, Count: 2},
+		},
+		Providers:    []ProviderCount{{Provider: "provider-aws-s3", Kinds: 1, Count: 3}},
+		Claims:       []NamespaceCount{{Namespace: "team-a", Count: 2}, {Namespace: "team-b", Count: 1}},
+		Compositions: []NameCount{{Name: "bucket", Count: 2}},
+	}
+	got, err := NewCollector(client).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("\nCollect(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nCollect(...): -want, +got:\n%s", diff)
+	}
+}
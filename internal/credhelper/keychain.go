@@ -0,0 +1,76 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credhelper
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	"github.com/upbound/up/internal/config"
+)
+
+// RegistryKeychain is an authn.Keychain that resolves the credentials stored
+// in a profile for arbitrary OCI registries.
+type RegistryKeychain struct {
+	h *Helper
+}
+
+// NewRegistryKeychain constructs a new RegistryKeychain. The profile and
+// source options are honored, all other helper options are ignored.
+func NewRegistryKeychain(opts ...Opt) *RegistryKeychain {
+	return &RegistryKeychain{
+		h: New(opts...),
+	}
+}
+
+// Resolve returns the credentials stored for the registry of the target. If
+// no credentials are stored, or the profile cannot be read, anonymous
+// credentials are returned so that other keychains may be consulted.
+func (k *RegistryKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	if err := k.h.src.Initialize(); err != nil {
+		return authn.Anonymous, nil //nolint:nilerr
+	}
+	conf, err := config.Extract(k.h.src)
+	if err != nil {
+		return authn.Anonymous, nil //nolint:nilerr
+	}
+	var p config.Profile
+	if k.h.profile == "" {
+		_, p, err = conf.GetDefaultUpboundProfile()
+	} else {
+		p, err = conf.GetUpboundProfile(k.h.profile)
+	}
+	if err != nil {
+		return authn.Anonymous, nil //nolint:nilerr
+	}
+	creds, ok := p.Registries[target.RegistryStr()]
+	if !ok {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authn.AuthConfig{
+		Username: creds.Username,
+		Password: creds.Password,
+	}), nil
+}
+
+// NewKeychain constructs a keychain that resolves credentials for the Upbound
+// registry using the credential helper, then for registries logged in to with
+// up xpkg login, and finally falls back to the default Docker keychain.
+func NewKeychain(opts ...Opt) authn.Keychain {
+	return authn.NewMultiKeychain(
+		authn.NewKeychainFromHelper(New(opts...)),
+		NewRegistryKeychain(opts...),
+		authn.DefaultKeychain,
+	)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credhelper
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/upbound/up/internal/config"
+)
+
+var _ authn.Keychain = &RegistryKeychain{}
+
+func TestRegistryKeychainResolve(t *testing.T) {
+	testProfile := "test"
+	src := &config.MockSource{
+		InitializeFn: func() error {
+			return nil
+		},
+		GetConfigFn: func() (*config.Config, error) {
+			return &config.Config{
+				Upbound: config.Upbound{
+					Profiles: map[string]config.Profile{
+						testProfile: {
+							Registries: map[string]config.RegistryCredentials{
+								"ghcr.io": {Username: "user", Password: "pass"},
+							},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		registry string
+		opts     []Opt
+		want     *authn.AuthConfig
+	}{
+		"NoProfile": {
+			reason:   "If the profile does not exist anonymous credentials should be returned.",
+			registry: "ghcr.io",
+			opts:     []Opt{WithProfile("missing"), WithSource(src)},
+			want:     &authn.AuthConfig{},
+		},
+		"UnknownRegistry": {
+			reason:   "If no credentials are stored for the registry anonymous credentials should be returned.",
+			registry: "quay.io",
+			opts:     []Opt{WithProfile(testProfile), WithSource(src)},
+			want:     &authn.AuthConfig{},
+		},
+		"Success": {
+			reason:   "If credentials are stored for the registry they should be returned.",
+			registry: "ghcr.io",
+			opts:     []Opt{WithProfile(testProfile), WithSource(src)},
+			want:     &authn.AuthConfig{Username: "user", Password: "pass"},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			reg, err := name.NewRegistry(tc.registry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth, err := NewRegistryKeychain(tc.opts...).Resolve(reg)
+			if err != nil {
+				t.Fatalf("\n%s\nResolve(...): unexpected error: %v", tc.reason, err)
+			}
+			got, err := auth.Authorization()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
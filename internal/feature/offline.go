@@ -0,0 +1,63 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"net"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// offlineTag is the struct field tag used to mark commands that can operate
+// without network access. The tag applies to all subcommands of a command.
+const offlineTag = "offline"
+
+const errFmtOffline = "%s requires network access and cannot be used with --offline"
+
+// Offline indicates whether network access has been disabled.
+type Offline bool
+
+// CheckOffline returns an error if the selected command has not been marked as
+// operating without network access.
+func CheckOffline(ctx *kong.Context) error {
+	cmd := []string{ctx.Model.Name}
+	for _, p := range ctx.Path {
+		if p.Command == nil {
+			continue
+		}
+		if p.Command.Tag.Has(offlineTag) {
+			return nil
+		}
+		cmd = append(cmd, p.Command.Name)
+	}
+	return OfflineError(strings.Join(cmd, " "))
+}
+
+// OfflineError returns the error of a command that was refused because it
+// requires network access.
+func OfflineError(command string) error {
+	return errors.Errorf(errFmtOffline, command)
+}
+
+// IsNetworkError returns true if the supplied error was caused by a failure to
+// reach a remote host, e.g. because no network is available.
+func IsNetworkError(err error) bool {
+	var ne net.Error
+	var oe *net.OpError
+	var de *net.DNSError
+	return errors.As(err, &oe) || errors.As(err, &de) || (errors.As(err, &ne) && ne.Timeout())
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/up/internal/audit"
+)
+
+// mutatingTag is the struct field tag used to mark commands that may change
+// state although their name does not say so, e.g. because they run arbitrary
+// commands.
+const mutatingTag = "mutating"
+
+const errFmtReadOnly = "%s changes state and cannot be used with --read-only"
+
+// ReadOnly indicates whether commands that change state have been disabled.
+type ReadOnly bool
+
+// A Mutator is a command that changes state depending on its flags, e.g. on
+// the HTTP method of a request.
+type Mutator interface {
+	Mutating() bool
+}
+
+// IsMutating returns true if the selected command changes state. A command
+// changes state if it, or one of its parents, is named after a mutating verb
+// or tagged as mutating, or if it is a Mutator that reports doing so.
+func IsMutating(ctx *kong.Context) bool {
+	for _, p := range ctx.Path {
+		if p.Command != nil && p.Command.Tag.Has(mutatingTag) {
+			return true
+		}
+	}
+	if audit.IsMutating(commandNames(ctx)) {
+		return true
+	}
+	if n := ctx.Selected(); n != nil && n.Target.CanAddr() {
+		if m, ok := n.Target.Addr().Interface().(Mutator); ok {
+			return m.Mutating()
+		}
+	}
+	return false
+}
+
+// CheckReadOnly returns an error if the selected command changes state.
+func CheckReadOnly(ctx *kong.Context) error {
+	if !IsMutating(ctx) {
+		return nil
+	}
+	return ReadOnlyError(strings.Join(append([]string{ctx.Model.Name}, commandNames(ctx)...), " "))
+}
+
+// commandNames returns the names of the selected command and its parents.
+func commandNames(ctx *kong.Context) []string {
+	cmd := []string{}
+	for _, p := range ctx.Path {
+		if p.Command != nil {
+			cmd = append(cmd, p.Command.Name)
+		}
+	}
+	return cmd
+}
+
+// ReadOnlyError returns the error of a command that was refused because it
+// changes state.
+func ReadOnlyError(command string) error {
+	return errors.Errorf(errFmtReadOnly, command)
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feature
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/google/go-cmp/cmp"
+)
+
+type requestCmd struct {
+	Method string `default:"GET"`
+}
+
+func (c *requestCmd) Mutating() bool {
+	return c.Method != "GET"
+}
+
+type testCLI struct {
+	Get     struct{}   `cmd:""`
+	Create  struct{}   `cmd:""`
+	Exec    struct{}   `cmd:"" mutating:""`
+	Request requestCmd `cmd:""`
+}
+
+func TestIsMutating(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		args   []string
+		want   bool
+	}{
+		"ReadOnlyVerb": {
+			reason: "Commands that are not named after a mutating verb should not change state.",
+			args:   []string{"get"},
+			want:   false,
+		},
+		"MutatingVerb": {
+			reason: "Commands named after a mutating verb should change state.",
+			args:   []string{"create"},
+			want:   true,
+		},
+		"MutatingTag": {
+			reason: "Commands tagged as mutating should change state.",
+			args:   []string{"exec"},
+			want:   true,
+		},
+		"MutatorSafe": {
+			reason: "A Mutator should decide whether it changes state.",
+			args:   []string{"request"},
+			want:   false,
+		},
+		"MutatorMutating": {
+			reason: "A Mutator should decide whether it changes state.",
+			args:   []string{"request", "--method", "POST"},
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			parser, err := kong.New(&testCLI{})
+			if err != nil {
+				t.Fatalf("kong.New(...): %v", err)
+			}
+			ctx, err := parser.Parse(tc.args)
+			if err != nil {
+				t.Fatalf("Parse(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, IsMutating(ctx)); diff != "" {
+				t.Errorf("\n%s\nIsMutating(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/pointer"
+
+	"github.com/upbound/up/internal/kube"
+	"github.com/upbound/up/internal/tracing"
+)
+
+const (
+	fieldManager = "up-fleet"
+
+	errListControlPlanes = "unable to list control planes"
+	errFmtApply          = "unable to apply control plane %s/%s"
+	errFmtDelete         = "unable to delete control plane %s/%s"
+	errFmtConnect        = "unable to connect to control plane %s/%s"
+	errFmtInstall        = "unable to install %s %s in control plane %s/%s"
+	errFmtUninstall      = "unable to remove %s %s from control plane %s/%s"
+	errFmtRecord         = "unable to record packages of control plane %s/%s"
+)
+
+var (
+	controlPlaneGVR = schema.GroupVersionResource{Group: "spaces.upbound.io", Version: "v1beta1", Resource: "controlplanes"}
+
+	packageGVKs = map[string]schema.GroupVersionKind{
+		ProviderKind:      {Group: "pkg.crossplane.io", Version: "v1", Kind: ProviderKind},
+		ConfigurationKind: {Group: "pkg.crossplane.io", Version: "v1", Kind: ConfigurationKind},
+		FunctionKind:      {Group: "pkg.crossplane.io", Version: "v1beta1", Kind: FunctionKind},
+	}
+	packageResources = map[string]string{
+		ProviderKind:      "providers",
+		ConfigurationKind: "configurations",
+		FunctionKind:      "functions",
+	}
+)
+
+// List returns the control planes of all groups in the Space.
+func List(ctx context.Context, client dynamic.Interface) ([]unstructured.Unstructured, error) {
+	l, err := client.Resource(controlPlaneGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListControlPlanes)
+	}
+	return l.Items, nil
+}
+
+// A ConnectFn returns a client for the API server of a control plane, or nil
+// if the control plane is not yet available.
+type ConnectFn func(ctx context.Context, group, name string) (dynamic.Interface, error)
+
+// Applier applies planned changes to the control planes of a Space.
+type Applier struct {
+	client  dynamic.Interface
+	fleet   string
+	connect ConnectFn
+}
+
+// ApplierOption modifies an Applier.
+type ApplierOption func(*Applier)
+
+// WithConnectFn sets how the Applier connects to control planes to manage
+// their packages.
+func WithConnectFn(fn ConnectFn) ApplierOption {
+	return func(a *Applier) {
+		a.connect = fn
+	}
+}
+
+// NewApplier constructs an Applier for the supplied fleet. By default it
+// connects to control planes using their connection secret.
+func NewApplier(client dynamic.Interface, fleet string, opts ...ApplierOption) *Applier {
+	a := &Applier{
+		client: client,
+		fleet:  fleet,
+	}
+	a.connect = a.connectWithSecret
+	for _, o := range opts {
+		o(a)
+	}
+	return a
+}
+
+// Apply applies a change. It returns false if the packages of the control
+// plane could not be reconciled yet because the control plane is not
+// available; applying the change again later completes it.
+func (a *Applier) Apply(ctx context.Context, c Change) (bool, error) {
+	switch c.Action {
+	case ActionNone:
+		return true, nil
+	case ActionDelete:
+		err := a.client.Resource(controlPlaneGVR).Namespace(c.Group).Delete(ctx, c.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, errFmtDelete, c.Group, c.Name)
+		}
+		return true, nil
+	}
+
+	if err := a.applyControlPlane(ctx, c.Desired); err != nil {
+		return false, errors.Wrapf(err, errFmtApply, c.Group, c.Name)
+	}
+	if len(c.Install) == 0 && len(c.Uninstall) == 0 {
+		return true, nil
+	}
+
+	cc, err := a.connect(ctx, c.Group, c.Name)
+	if err != nil {
+		return false, errors.Wrapf(err, errFmtConnect, c.Group, c.Name)
+	}
+	if cc == nil {
+		return false, nil
+	}
+	for _, p := range c.Install {
+		if err := applyPackage(ctx, cc, p); err != nil {
+			return false, errors.Wrapf(err, errFmtInstall, p.Kind, p.Package, c.Group, c.Name)
+		}
+	}
+	for _, p := range c.Uninstall {
+		err := cc.Resource(packageGVKs[p.Kind].GroupVersion().WithResource(packageResources[p.Kind])).Delete(ctx, p.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, errFmtUninstall, p.Kind, p.Package, c.Group, c.Name)
+		}
+	}
+	if err := a.recordPackages(ctx, c.Desired); err != nil {
+		return false, errors.Wrapf(err, errFmtRecord, c.Group, c.Name)
+	}
+	return true, nil
+}
+
+// applyControlPlane creates or updates the supplied control plane. The
+// connection secret of a new control plane is written to its group; that of
+// an existing control plane is left where it is, as other tools may read it.
+func (a *Applier) applyControlPlane(ctx context.Context, d *ControlPlane) error {
+	spec := map[string]any{}
+	existing, err := a.client.Resource(controlPlaneGVR).Namespace(d.Group).Get(ctx, d.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		spec["writeConnectionSecretToRef"] = map[string]any{
+			"name":      kube.ConnectionSecretName(d.Name),
+			"namespace": d.Group,
+		}
+	case err != nil:
+		return err
+	default:
+		// Keep an existing ref in the applied configuration, otherwise
+		// applying without it would remove a ref set by an earlier apply.
+		if ref, ok, _ := unstructured.NestedMap(existing.Object, "spec", "writeConnectionSecretToRef"); ok {
+			spec["writeConnectionSecretToRef"] = ref
+		}
+	}
+	if d.Class != "" {
+		spec["class"] = d.Class
+	}
+	if d.CrossplaneVersion != "" {
+		spec["crossplane"] = map[string]any{"version": d.CrossplaneVersion}
+	}
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetGroupVersionKind(controlPlaneGVR.GroupVersion().WithKind("ControlPlane"))
+	u.SetNamespace(d.Group)
+	u.SetName(d.Name)
+	u.SetLabels(map[string]string{NameLabel: a.fleet})
+	return serverSideApply(ctx, a.client.Resource(controlPlaneGVR).Namespace(d.Group), u)
+}
+
+func (a *Applier) recordPackages(ctx context.Context, d *ControlPlane) error {
+	pkgs, err := json.Marshal(d.Packages)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{PackagesAnnotation: string(pkgs)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = a.client.Resource(controlPlaneGVR).Namespace(d.Group).Patch(ctx, d.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: fieldManager})
+	return err
+}
+
+// connectWithSecret connects to a control plane using the kubeconfig in its
+// connection secret, which is written once the control plane is available.
+func (a *Applier) connectWithSecret(ctx context.Context, group, name string) (dynamic.Interface, error) {
+	ctp, err := a.client.Resource(controlPlaneGVR).Namespace(group).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ns, secret := kube.ConnectionSecretRef(ctp)
+	kubeconfig, err := kube.ConnectionSecretKubeconfig(ctx, a.client, ns, secret)
+	if err != nil || kubeconfig == nil {
+		return nil, err
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Wrap(tracing.Transport)
+	return dynamic.NewForConfig(cfg)
+}
+
+func applyPackage(ctx context.Context, client dynamic.Interface, p Package) error {
+	gvk := packageGVKs[p.Kind]
+	u := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"package": p.Package},
+	}}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(p.Name)
+	return serverSideApply(ctx, client.Resource(gvk.GroupVersion().WithResource(packageResources[p.Kind])), u)
+}
+
+func serverSideApply(ctx context.Context, r dynamic.ResourceInterface, u *unstructured.Unstructured) error {
+	b, err := json.Marshal(u.Object)
+	if err != nil {
+		return err
+	}
+	_, err = r.Patch(ctx, u.GetName(), types.ApplyPatchType, b, metav1.PatchOptions{FieldManager: fieldManager, Force: pointer.Bool(true)})
+	return err
+}
@@ -0,0 +1,280 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fleet declaratively manages a fleet of control planes in a Space.
+package fleet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/upbound/up/internal/xpkg"
+)
+
+const (
+	// NameLabel is the label that marks a control plane as managed by the
+	// fleet of the given name.
+	NameLabel = "fleet.up.upbound.io/name"
+
+	// PackagesAnnotation records the packages last installed in a control
+	// plane by its fleet.
+	PackagesAnnotation = "fleet.up.upbound.io/packages"
+
+	// DefaultGroup is the control plane group used if none is declared.
+	DefaultGroup = "default"
+
+	errParse            = "unable to parse fleet"
+	errNoName           = "fleet name is required"
+	errFmtNoCtpName     = "control plane %d: name is required"
+	errFmtDuplicateCtp  = "control plane %s/%s is declared more than once"
+	errFmtPackageKind   = "control plane %s/%s: package %q has unsupported kind %q"
+	errFmtPackageRef    = "control plane %s/%s: invalid package %q"
+	errFmtDuplicatePkg  = "control plane %s/%s: package name %q is declared more than once"
+	errFmtBadAnnotation = "control plane %s/%s: invalid %s annotation"
+)
+
+// Supported package kinds.
+const (
+	ProviderKind      = "Provider"
+	ConfigurationKind = "Configuration"
+	FunctionKind      = "Function"
+)
+
+// Fleet is the desired state of a fleet of control planes.
+type Fleet struct {
+	// Name of the fleet. Control planes created by the fleet are labelled
+	// with it, and only those are deleted when no longer declared.
+	Name string `json:"name"`
+
+	ControlPlanes []ControlPlane `json:"controlPlanes"`
+}
+
+// ControlPlane is the desired state of a control plane.
+type ControlPlane struct {
+	Name              string    `json:"name"`
+	Group             string    `json:"group,omitempty"`
+	Class             string    `json:"class,omitempty"`
+	CrossplaneVersion string    `json:"crossplaneVersion,omitempty"`
+	Packages          []Package `json:"packages,omitempty"`
+}
+
+// Package is a Crossplane package installed in a control plane.
+type Package struct {
+	Kind    string `json:"kind"`
+	Package string `json:"package"`
+	Name    string `json:"name,omitempty"`
+}
+
+// Parse parses and validates a fleet, and defaults the group of control
+// planes and the name of packages.
+func Parse(b []byte) (*Fleet, error) { //nolint:gocyclo
+	f := &Fleet{}
+	if err := yaml.UnmarshalStrict(b, f); err != nil {
+		return nil, errors.Wrap(err, errParse)
+	}
+	if f.Name == "" {
+		return nil, errors.New(errNoName)
+	}
+	seen := map[string]bool{}
+	for i := range f.ControlPlanes {
+		c := &f.ControlPlanes[i]
+		if c.Name == "" {
+			return nil, errors.Errorf(errFmtNoCtpName, i)
+		}
+		if c.Group == "" {
+			c.Group = DefaultGroup
+		}
+		if seen[c.key()] {
+			return nil, errors.Errorf(errFmtDuplicateCtp, c.Group, c.Name)
+		}
+		seen[c.key()] = true
+
+		pkgs := map[string]bool{}
+		for j := range c.Packages {
+			p := &c.Packages[j]
+			switch p.Kind {
+			case ProviderKind, ConfigurationKind, FunctionKind:
+			default:
+				return nil, errors.Errorf(errFmtPackageKind, c.Group, c.Name, p.Package, p.Kind)
+			}
+			ref, err := name.ParseReference(p.Package)
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtPackageRef, c.Group, c.Name, p.Package)
+			}
+			if p.Name == "" {
+				p.Name = xpkg.ToDNSLabel(ref.Context().RepositoryStr())
+			}
+			k := p.Kind + "/" + p.Name
+			if pkgs[k] {
+				return nil, errors.Errorf(errFmtDuplicatePkg, c.Group, c.Name, p.Name)
+			}
+			pkgs[k] = true
+		}
+	}
+	return f, nil
+}
+
+func (c ControlPlane) key() string {
+	return c.Group + "/" + c.Name
+}
+
+// Action is the action taken on a control plane to reach its desired state.
+type Action string
+
+// Actions of a plan.
+const (
+	ActionNone   Action = "no-op"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a planned change to a control plane.
+type Change struct {
+	Action Action
+	Group  string
+	Name   string
+
+	// Diffs describes the changes to the control plane.
+	Diffs []string
+
+	// Desired is the desired state of the control plane. It is nil for
+	// deletions.
+	Desired *ControlPlane
+
+	// Install and Uninstall are the packages to install in, or remove
+	// from, the control plane.
+	Install   []Package
+	Uninstall []Package
+}
+
+// Plan computes the changes needed to make the supplied existing control
+// planes match the fleet. Changes are ordered by group and name.
+func Plan(f *Fleet, existing []unstructured.Unstructured) ([]Change, error) {
+	actual := make(map[string]*unstructured.Unstructured, len(existing))
+	for i := range existing {
+		u := &existing[i]
+		actual[u.GetNamespace()+"/"+u.GetName()] = u
+	}
+
+	changes := []Change{}
+	for i := range f.ControlPlanes {
+		d := &f.ControlPlanes[i]
+		u, ok := actual[d.key()]
+		if !ok {
+			changes = append(changes, Change{
+				Action:  ActionCreate,
+				Group:   d.Group,
+				Name:    d.Name,
+				Desired: d,
+				Install: d.Packages,
+				Diffs:   packageDiffs(d.Packages, nil),
+			})
+			continue
+		}
+		delete(actual, d.key())
+		c, err := planUpdate(f.Name, d, u)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	for _, u := range actual {
+		if u.GetLabels()[NameLabel] != f.Name {
+			continue
+		}
+		changes = append(changes, Change{Action: ActionDelete, Group: u.GetNamespace(), Name: u.GetName()})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Group != changes[j].Group {
+			return changes[i].Group < changes[j].Group
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+func planUpdate(fleet string, d *ControlPlane, u *unstructured.Unstructured) (Change, error) {
+	c := Change{Action: ActionNone, Group: d.Group, Name: d.Name, Desired: d}
+	if u.GetLabels()[NameLabel] != fleet {
+		c.Diffs = append(c.Diffs, "adopt into fleet")
+	}
+	p := fieldpath.Pave(u.Object)
+	if d.Class != "" {
+		if cur, _ := p.GetString("spec.class"); cur != d.Class {
+			c.Diffs = append(c.Diffs, fmt.Sprintf("class: %q -> %q", cur, d.Class))
+		}
+	}
+	if d.CrossplaneVersion != "" {
+		if cur, _ := p.GetString("spec.crossplane.version"); cur != d.CrossplaneVersion {
+			c.Diffs = append(c.Diffs, fmt.Sprintf("crossplane version: %q -> %q", cur, d.CrossplaneVersion))
+		}
+	}
+
+	installed := []Package{}
+	if a, ok := u.GetAnnotations()[PackagesAnnotation]; ok {
+		if err := json.Unmarshal([]byte(a), &installed); err != nil {
+			return Change{}, errors.Wrapf(err, errFmtBadAnnotation, d.Group, d.Name, PackagesAnnotation)
+		}
+	}
+	c.Install, c.Uninstall = diffPackages(d.Packages, installed)
+	c.Diffs = append(c.Diffs, packageDiffs(c.Install, c.Uninstall)...)
+
+	if len(c.Diffs) > 0 {
+		c.Action = ActionUpdate
+	}
+	return c, nil
+}
+
+// diffPackages returns the desired packages that are not installed, and the
+// installed packages that are no longer desired. Packages are identified by
+// kind and name.
+func diffPackages(desired, installed []Package) (install, uninstall []Package) {
+	cur := make(map[string]Package, len(installed))
+	for _, p := range installed {
+		cur[p.Kind+"/"+p.Name] = p
+	}
+	for _, p := range desired {
+		k := p.Kind + "/" + p.Name
+		if i, ok := cur[k]; !ok || i.Package != p.Package {
+			install = append(install, p)
+		}
+		delete(cur, k)
+	}
+	for _, p := range installed {
+		if _, ok := cur[p.Kind+"/"+p.Name]; ok {
+			uninstall = append(uninstall, p)
+		}
+	}
+	return install, uninstall
+}
+
+func packageDiffs(install, uninstall []Package) []string {
+	diffs := make([]string, 0, len(install)+len(uninstall))
+	for _, p := range install {
+		diffs = append(diffs, fmt.Sprintf("+%s %s", p.Kind, p.Package))
+	}
+	for _, p := range uninstall {
+		diffs = append(diffs, fmt.Sprintf("-%s %s", p.Kind, p.Package))
+	}
+	return diffs
+}
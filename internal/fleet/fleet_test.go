@@ -0,0 +1,266 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     string
+		want   *Fleet
+		err    error
+	}{
+		"NoName": {
+			reason: "A fleet must have a name.",
+			in:     "controlPlanes: []",
+			err:    errors.New(errNoName),
+		},
+		"Duplicate": {
+			reason: "A control plane must not be declared twice in the same group.",
+			in: `
+name: cool
+controlPlanes:
+- name: ctp1
+- name: ctp1
+  group: default`,
+			err: errors.Errorf(errFmtDuplicateCtp, "default", "ctp1"),
+		},
+		"UnsupportedKind": {
+			reason: "Packages must be of a supported kind.",
+			in: `
+name: cool
+controlPlanes:
+- name: ctp1
+  packages:
+  - kind: Composition
+    package: xpkg.upbound.io/upbound/cool:v1.0.0`,
+			err: errors.Errorf(errFmtPackageKind, "default", "ctp1", "xpkg.upbound.io/upbound/cool:v1.0.0", "Composition"),
+		},
+		"Defaults": {
+			reason: "The group of control planes and the name of packages should be defaulted.",
+			in: `
+name: cool
+controlPlanes:
+- name: ctp1
+  crossplaneVersion: 1.14.1
+  packages:
+  - kind: Provider
+    package: xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0
+- name: ctp2
+  group: team
+  class: small`,
+			want: &Fleet{
+				Name: "cool",
+				ControlPlanes: []ControlPlane{
+					{
+						Name:              "ctp1",
+						Group:             DefaultGroup,
+						CrossplaneVersion: "1.14.1",
+						Packages: []Package{
+							{Kind: ProviderKind, Package: "xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0", Name: "upbound-provider-aws-s3"},
+						},
+					},
+					{Name: "ctp2", Group: "team", Class: "small"},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse([]byte(tc.in))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func controlPlane(group, name string, labels, annotations map[string]string, spec map[string]any) unstructured.Unstructured {
+	u := unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetNamespace(group)
+	u.SetName(name)
+	u.SetLabels(labels)
+	u.SetAnnotations(annotations)
+	return u
+}
+
+func TestPlan(t *testing.T) {
+	s3 := Package{Kind: ProviderKind, Package: "xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0", Name: "upbound-provider-aws-s3"}
+	s3New := Package{Kind: ProviderKind, Package: "xpkg.upbound.io/upbound/provider-aws-s3:v1.1.0", Name: "upbound-provider-aws-s3"}
+	ec2 := Package{Kind: ProviderKind, Package: "xpkg.upbound.io/upbound/provider-aws-ec2:v1.0.0", Name: "upbound-provider-aws-ec2"}
+	managed := map[string]string{NameLabel: "cool"}
+
+	cases := map[string]struct {
+		reason   string
+		fleet    *Fleet
+		existing []unstructured.Unstructured
+		want     []Change
+		err      error
+	}{
+		"Create": {
+			reason: "Control planes that do not exist should be created with their packages.",
+			fleet:  &Fleet{Name: "cool", ControlPlanes: []ControlPlane{{Name: "ctp1", Group: "default", Packages: []Package{s3}}}},
+			want: []Change{{
+				Action:  ActionCreate,
+				Group:   "default",
+				Name:    "ctp1",
+				Diffs:   []string{"+Provider " + s3.Package},
+				Desired: &ControlPlane{Name: "ctp1", Group: "default", Packages: []Package{s3}},
+				Install: []Package{s3},
+			}},
+		},
+		"NoOp": {
+			reason: "Control planes that match the fleet should not be changed.",
+			fleet:  &Fleet{Name: "cool", ControlPlanes: []ControlPlane{{Name: "ctp1", Group: "default", Class: "small", Packages: []Package{s3}}}},
+			existing: []unstructured.Unstructured{
+				controlPlane("default", "ctp1", managed, map[string]string{PackagesAnnotation: `[{"kind":"Provider","package":"xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0","name":"upbound-provider-aws-s3"}]`}, map[string]any{"class": "small"}),
+			},
+			want: []Change{{
+				Action:  ActionNone,
+				Group:   "default",
+				Name:    "ctp1",
+				Desired: &ControlPlane{Name: "ctp1", Group: "default", Class: "small", Packages: []Package{s3}},
+			}},
+		},
+		"Update": {
+			reason: "Control planes that differ from the fleet should be updated and adopted.",
+			fleet:  &Fleet{Name: "cool", ControlPlanes: []ControlPlane{{Name: "ctp1", Group: "default", CrossplaneVersion: "1.14.1", Packages: []Package{s3New}}}},
+			existing: []unstructured.Unstructured{
+				controlPlane("default", "ctp1", nil, map[string]string{PackagesAnnotation: `[{"kind":"Provider","package":"xpkg.upbound.io/upbound/provider-aws-s3:v1.0.0","name":"upbound-provider-aws-s3"},{"kind":"Provider","package":"xpkg.upbound.io/upbound/provider-aws-ec2:v1.0.0","name":"upbound-provider-aws-ec2"}]`}, map[string]any{"crossplane": map[string]any{"version": "1.13.2"}}),
+			},
+			want: []Change{{
+				Action: ActionUpdate,
+				Group:  "default",
+				Name:   "ctp1",
+				Diffs: []string{
+					"adopt into fleet",
+					`crossplane version: "1.13.2" -> "1.14.1"`,
+					"+Provider " + s3New.Package,
+					"-Provider " + ec2.Package,
+				},
+				Desired:   &ControlPlane{Name: "ctp1", Group: "default", CrossplaneVersion: "1.14.1", Packages: []Package{s3New}},
+				Install:   []Package{s3New},
+				Uninstall: []Package{ec2},
+			}},
+		},
+		"Delete": {
+			reason: "Only control planes of the fleet that are no longer declared should be deleted.",
+			fleet:  &Fleet{Name: "cool"},
+			existing: []unstructured.Unstructured{
+				controlPlane("team", "ctp2", managed, nil, nil),
+				controlPlane("team", "unmanaged", nil, nil, nil),
+				controlPlane("team", "other", map[string]string{NameLabel: "other"}, nil, nil),
+			},
+			want: []Change{{Action: ActionDelete, Group: "team", Name: "ctp2"}},
+		},
+		"ErrBadAnnotation": {
+			reason: "An invalid packages annotation should return an error.",
+			fleet:  &Fleet{Name: "cool", ControlPlanes: []ControlPlane{{Name: "ctp1", Group: "default"}}},
+			existing: []unstructured.Unstructured{
+				controlPlane("default", "ctp1", managed, map[string]string{PackagesAnnotation: "{"}, nil),
+			},
+			err: errors.Wrapf(errors.New("unexpected end of JSON input"), errFmtBadAnnotation, "default", "ctp1", PackagesAnnotation),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Plan(tc.fleet, tc.existing)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPlan(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPlan(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyControlPlane(t *testing.T) {
+	otherRef := map[string]any{"name": "ctp1-kubeconfig", "namespace": "secrets"}
+
+	cases := map[string]struct {
+		reason   string
+		existing []runtime.Object
+		want     map[string]any
+	}{
+		"Create": {
+			reason: "The connection secret of a new control plane should be written to its group.",
+			want: map[string]any{
+				"writeConnectionSecretToRef": map[string]any{"name": "kubeconfig-ctp1", "namespace": "default"},
+			},
+		},
+		"ExistingRef": {
+			reason: "The connection secret ref of an existing control plane should be kept.",
+			existing: []runtime.Object{
+				controlPlaneObject(map[string]any{"writeConnectionSecretToRef": otherRef}),
+			},
+			want: map[string]any{"writeConnectionSecretToRef": otherRef},
+		},
+		"ExistingNoRef": {
+			reason: "No connection secret ref should be set on an existing control plane without one.",
+			existing: []runtime.Object{
+				controlPlaneObject(map[string]any{}),
+			},
+			want: map[string]any{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				controlPlaneGVR: "ControlPlaneList",
+			}, tc.existing...)
+			var applied map[string]any
+			client.PrependReactor("patch", "controlplanes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				u := map[string]any{}
+				if err := json.Unmarshal(action.(k8stesting.PatchAction).GetPatch(), &u); err != nil {
+					return true, nil, err
+				}
+				applied, _, _ = unstructured.NestedMap(u, "spec")
+				return true, nil, nil
+			})
+
+			a := NewApplier(client, "cool")
+			if err := a.applyControlPlane(context.Background(), &ControlPlane{Name: "ctp1", Group: "default"}); err != nil {
+				t.Fatalf("applyControlPlane(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, applied); diff != "" {
+				t.Errorf("\n%s\napplyControlPlane(...): -want spec, +got spec:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func controlPlaneObject(spec map[string]any) *unstructured.Unstructured {
+	u := controlPlane("default", "ctp1", nil, nil, spec)
+	u.SetGroupVersionKind(controlPlaneGVR.GroupVersion().WithKind("ControlPlane"))
+	return &u
+}
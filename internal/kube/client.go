@@ -20,11 +20,14 @@ import (
 	"path"
 	"strings"
 
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/transport"
+
+	"github.com/upbound/up/internal/tracing"
 )
 
 const (
@@ -38,6 +41,8 @@ const (
 
 	// UpboundK8sResource is appended to the end of the kubeconfig server path.
 	UpboundK8sResource = "k8s"
+
+	errFmtContextNotFound = "context %s not found in kubeconfig"
 )
 
 // GetKubeConfig constructs a Kubernetes REST config from the specified
@@ -45,13 +50,18 @@ const (
 func GetKubeConfig(path string) (*rest.Config, error) {
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
 	rules.ExplicitPath = path
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Wrap(tracing.Transport)
+	return restConfig, nil
 }
 
 // BuildControlPlaneKubeconfig builds a kubeconfig entry for a control plane.
 func BuildControlPlaneKubeconfig(proxy *url.URL, id string, token string) *api.Config { //nolint:interfacer
 	conf := api.NewConfig()
-	key := fmt.Sprintf(UpboundKubeconfigKeyFmt, strings.ReplaceAll(id, "/", "-"))
+	key := ControlPlaneContext(id)
 	proxy.Path = path.Join(proxy.Path, id, UpboundK8sResource)
 	conf.Clusters[key] = &api.Cluster{
 		Server: proxy.String(),
@@ -67,6 +77,31 @@ func BuildControlPlaneKubeconfig(proxy *url.URL, id string, token string) *api.C
 	return conf
 }
 
+// ControlPlaneContext returns the name of the kubeconfig context of the
+// control plane with the given ID (account/name).
+func ControlPlaneContext(id string) string {
+	return fmt.Sprintf(UpboundKubeconfigKeyFmt, strings.ReplaceAll(id, "/", "-"))
+}
+
+// GetControlPlaneKubeConfig constructs a Kubernetes REST config for the control
+// plane with the given ID (account/name) that authenticates with the supplied
+// token through the Upbound proxy.
+func GetControlPlaneKubeConfig(proxy *url.URL, id, token string, wrapTransport transport.WrapperFunc) (*rest.Config, error) {
+	// NOTE: BuildControlPlaneKubeconfig modifies the path of the supplied URL,
+	// so we pass a copy to avoid mutating the caller's proxy endpoint.
+	p := *proxy
+	conf := BuildControlPlaneKubeconfig(&p, id, token)
+	restConfig, err := clientcmd.NewDefaultClientConfig(*conf, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Wrap(tracing.Transport)
+	if wrapTransport != nil {
+		restConfig.Wrap(wrapTransport)
+	}
+	return restConfig, nil
+}
+
 // ApplyControlPlaneKubeconfig applies a control plane kubeconfig to an existing
 // kubeconfig file and sets it as the current context.
 func ApplyControlPlaneKubeconfig(mcpConf *api.Config, existingFilePath string, wrapTransport transport.WrapperFunc) error {
@@ -116,3 +151,43 @@ func ApplyControlPlaneKubeconfig(mcpConf *api.Config, existingFilePath string, w
 
 	return clientcmd.ModifyConfig(po, *conf, true)
 }
+
+// UseContext sets the current context of an existing kubeconfig file, or of
+// the default kubeconfig, to the supplied context. If a namespace is supplied
+// it is set as the namespace of the context.
+func UseContext(existingFilePath, context, namespace string) error {
+	po := clientcmd.NewDefaultPathOptions()
+	po.LoadingRules.ExplicitPath = existingFilePath
+	conf, err := po.GetStartingConfig()
+	if err != nil {
+		return err
+	}
+	c, ok := conf.Contexts[context]
+	if !ok {
+		return errors.Errorf(errFmtContextNotFound, context)
+	}
+	if namespace != "" {
+		c.Namespace = namespace
+	}
+	conf.CurrentContext = context
+	return clientcmd.ModifyConfig(po, *conf, true)
+}
+
+// CurrentContext returns the name of the current context of the supplied
+// kubeconfig, or of the default kubeconfig, and the server of its cluster.
+func CurrentContext(path string) (context, server string, err error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = path
+	conf, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return "", "", err
+	}
+	c, ok := conf.Contexts[conf.CurrentContext]
+	if !ok {
+		return "", "", errors.Errorf(errFmtContextNotFound, conf.CurrentContext)
+	}
+	if cl, ok := conf.Clusters[c.Cluster]; ok {
+		server = cl.Server
+	}
+	return conf.CurrentContext, server, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// ConnectionSecretKubeconfigKey is the key of the kubeconfig in the
+// connection secret of a Space control plane.
+const ConnectionSecretKubeconfigKey = "kubeconfig"
+
+var secretGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+// ConnectionSecretName returns the name of the connection secret of a Space
+// control plane if its writeConnectionSecretToRef is not set.
+func ConnectionSecretName(controlPlane string) string {
+	return fmt.Sprintf("kubeconfig-%s", controlPlane)
+}
+
+// ConnectionSecretRef returns the namespace and name of the connection secret
+// of the supplied Space control plane.
+func ConnectionSecretRef(ctp *unstructured.Unstructured) (namespace, name string) {
+	namespace, _, _ = unstructured.NestedString(ctp.Object, "spec", "writeConnectionSecretToRef", "namespace")
+	name, _, _ = unstructured.NestedString(ctp.Object, "spec", "writeConnectionSecretToRef", "name")
+	if namespace == "" {
+		namespace = ctp.GetNamespace()
+	}
+	if name == "" {
+		name = ConnectionSecretName(ctp.GetName())
+	}
+	return namespace, name
+}
+
+// ConnectionSecretKubeconfig returns the kubeconfig stored in the connection
+// secret of a Space control plane. It returns nil if the secret has not been
+// written yet, i.e. the control plane is not yet available.
+func ConnectionSecretKubeconfig(ctx context.Context, client dynamic.Interface, namespace, name string) ([]byte, error) {
+	s, err := client.Resource(secretGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	enc, _, _ := unstructured.NestedString(s.Object, "data", ConnectionSecretKubeconfigKey)
+	if enc == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(enc)
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestConnectionSecretKubeconfig(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"namespace": "default", "name": "kubeconfig-ctp1"},
+		"data":       map[string]any{ConnectionSecretKubeconfigKey: base64.StdEncoding.EncodeToString([]byte("cool-kubeconfig"))},
+	}}
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), secret)
+
+	cases := map[string]struct {
+		reason string
+		ctp    *unstructured.Unstructured
+		want   []byte
+	}{
+		"DefaultRef": {
+			reason: "The kubeconfig should be read from the default connection secret.",
+			ctp: &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"namespace": "default", "name": "ctp1"},
+			}},
+			want: []byte("cool-kubeconfig"),
+		},
+		"NotAvailable": {
+			reason: "No kubeconfig should be returned if the connection secret does not exist.",
+			ctp: &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"namespace": "default", "name": "ctp1"},
+				"spec": map[string]any{
+					"writeConnectionSecretToRef": map[string]any{"name": "other", "namespace": "team"},
+				},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ns, n := ConnectionSecretRef(tc.ctp)
+			got, err := ConnectionSecretKubeconfig(context.Background(), client, ns, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConnectionSecretKubeconfig(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
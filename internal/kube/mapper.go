@@ -0,0 +1,68 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	errFmtResolveResource = "unable to resolve resource %q"
+)
+
+// NewDiscoveryRESTMapper constructs a RESTMapper backed by an in-memory cached
+// discovery client for the given config. Short names (e.g. "xrd") are expanded
+// in the same way as kubectl.
+func NewDiscoveryRESTMapper(cfg *rest.Config) (meta.RESTMapper, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cached := memory.NewMemCacheClient(dc)
+	return restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cached), cached), nil
+}
+
+// MappingFor resolves a resource argument, such as "providers",
+// "providers.pkg.crossplane.io" or "Provider", into a REST mapping.
+func MappingFor(mapper meta.RESTMapper, resource string) (*meta.RESTMapping, error) {
+	fullySpecified, gr := schema.ParseResourceArg(resource)
+	gvk := schema.GroupVersionKind{}
+	if fullySpecified != nil {
+		gvk, _ = mapper.KindFor(*fullySpecified)
+	}
+	if gvk.Empty() {
+		gvk, _ = mapper.KindFor(gr.WithVersion(""))
+	}
+	if gvk.Empty() {
+		// Fall back to interpreting the argument as a kind.
+		gk := schema.ParseGroupKind(resource)
+		m, err := mapper.RESTMapping(gk)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtResolveResource, resource)
+		}
+		return m, nil
+	}
+	m, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtResolveResource, resource)
+	}
+	return m, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// crossplaneResourceFields are spec fields of claims, composite resources and
+// managed resources. Crossplane sets or defaults at least one of them, so they
+// are present even if the manifest did not set them.
+var crossplaneResourceFields = []string{
+	"compositeDeletePolicy",
+	"compositionRef",
+	"compositionSelector",
+	"compositionRevisionRef",
+	"compositionUpdatePolicy",
+	"resourceRef",
+	"resourceRefs",
+	"forProvider",
+	"deletionPolicy",
+	"providerConfigRef",
+}
+
+// requiredConditions returns the condition types that must be reported and
+// true for the supplied object to be considered ready.
+func requiredConditions(u *unstructured.Unstructured) []xpv1.ConditionType {
+	gk := u.GroupVersionKind().GroupKind()
+	switch {
+	case gk.Group == "pkg.crossplane.io" && (gk.Kind == "Provider" || gk.Kind == "Configuration" || gk.Kind == "Function"):
+		return []xpv1.ConditionType{"Installed", "Healthy"}
+	case gk.Group == "apiextensions.k8s.io" && gk.Kind == "CustomResourceDefinition":
+		return []xpv1.ConditionType{"Established"}
+	case gk.Group == "apiextensions.crossplane.io" && gk.Kind == "CompositeResourceDefinition":
+		if _, ok, _ := unstructured.NestedMap(u.Object, "spec", "claimNames"); ok {
+			return []xpv1.ConditionType{"Established", "Offered"}
+		}
+		return []xpv1.ConditionType{"Established"}
+	}
+	spec, _, _ := unstructured.NestedMap(u.Object, "spec")
+	for _, f := range crossplaneResourceFields {
+		if _, ok := spec[f]; ok {
+			return []xpv1.ConditionType{xpv1.TypeReady}
+		}
+	}
+	return nil
+}
+
+// IsReady returns true if the supplied object is ready. Packages must be
+// installed and healthy, CRDs and XRDs established, and claims, composite
+// and managed resources ready. Other objects are ready if the Ready and
+// Synced conditions they report, if any, are true. Objects that report no
+// conditions, such as ConfigMaps, are always ready.
+func IsReady(u *unstructured.Unstructured) bool {
+	conditioned := xpv1.ConditionedStatus{}
+	_ = fieldpath.Pave(u.Object).GetValueInto("status", &conditioned)
+	for _, ct := range requiredConditions(u) {
+		if conditioned.GetCondition(ct).Status != corev1.ConditionTrue {
+			return false
+		}
+	}
+	for _, ct := range []xpv1.ConditionType{xpv1.TypeReady, xpv1.TypeSynced} {
+		c := conditioned.GetCondition(ct)
+		// GetCondition returns an Unknown condition with no reason if the
+		// condition is not reported.
+		if c.Status == corev1.ConditionUnknown && c.Reason == "" && c.LastTransitionTime.IsZero() {
+			continue
+		}
+		if c.Status != corev1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
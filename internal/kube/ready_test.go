@@ -0,0 +1,107 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsReady(t *testing.T) {
+	withConditions := func(apiVersion, kind string, conds ...map[string]any) *unstructured.Unstructured {
+		c := make([]any, len(conds))
+		for i := range conds {
+			c[i] = conds[i]
+		}
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"status":     map[string]any{"conditions": c},
+		}}
+	}
+	claim := func(conds ...map[string]any) *unstructured.Unstructured {
+		u := withConditions("example.org/v1alpha1", "Cluster", conds...)
+		u.Object["spec"] = map[string]any{"compositionUpdatePolicy": "Automatic"}
+		return u
+	}
+	cond := func(t, s string) map[string]any {
+		return map[string]any{"type": t, "status": s, "reason": "Test", "lastTransitionTime": "2023-01-01T00:00:00Z"}
+	}
+
+	cases := map[string]struct {
+		reason string
+		u      *unstructured.Unstructured
+		want   bool
+	}{
+		"NoStatus": {
+			reason: "Objects without conditions should be ready.",
+			u:      &unstructured.Unstructured{Object: map[string]any{"data": map[string]any{}}},
+			want:   true,
+		},
+		"HealthyPackage": {
+			reason: "A package that is installed and healthy should be ready.",
+			u:      withConditions("pkg.crossplane.io/v1", "Provider", cond("Installed", "True"), cond("Healthy", "True")),
+			want:   true,
+		},
+		"UnhealthyPackage": {
+			reason: "A package that is not healthy should not be ready.",
+			u:      withConditions("pkg.crossplane.io/v1", "Provider", cond("Installed", "True"), cond("Healthy", "False")),
+			want:   false,
+		},
+		"NewPackage": {
+			reason: "A package that does not report being healthy yet should not be ready.",
+			u:      withConditions("pkg.crossplane.io/v1", "Configuration", cond("Installed", "True")),
+			want:   false,
+		},
+		"PackageWithoutStatus": {
+			reason: "A package without a status should not be ready.",
+			u:      &unstructured.Unstructured{Object: map[string]any{"apiVersion": "pkg.crossplane.io/v1", "kind": "Provider"}},
+			want:   false,
+		},
+		"EstablishedCRD": {
+			reason: "An established CRD should be ready.",
+			u:      withConditions("apiextensions.k8s.io/v1", "CustomResourceDefinition", cond("Established", "True")),
+			want:   true,
+		},
+		"NewXRD": {
+			reason: "An XRD that is not established yet should not be ready.",
+			u:      withConditions("apiextensions.crossplane.io/v1", "CompositeResourceDefinition"),
+			want:   false,
+		},
+		"ReadyClaim": {
+			reason: "A claim that is ready should be ready.",
+			u:      claim(cond("Synced", "True"), cond("Ready", "True")),
+			want:   true,
+		},
+		"SyncedClaim": {
+			reason: "A claim that is synced but not ready should not be ready.",
+			u:      claim(cond("Synced", "True"), cond("Ready", "False")),
+			want:   false,
+		},
+		"NewClaim": {
+			reason: "A claim that does not report being ready yet should not be ready.",
+			u:      claim(),
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsReady(tc.u); got != tc.want {
+				t.Errorf("\n%s\nIsReady(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
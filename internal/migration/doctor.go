@@ -0,0 +1,299 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migration contains utilities for migrating Crossplane control planes
+// to Upbound managed control planes.
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/resources"
+)
+
+const (
+	// MaxSecretSize is the size in bytes above which a Secret is reported as
+	// oversized. It leaves headroom below the 1MiB limit enforced by etcd for
+	// the annotations added during a migration.
+	MaxSecretSize = 900 * 1024
+
+	errListCRDs    = "unable to list custom resource definitions"
+	errListSecrets = "unable to list secrets"
+
+	errFmtList = "unable to list %s"
+)
+
+// Names of the checks performed by the Doctor.
+const (
+	CheckDeprecatedAPIs   = "DeprecatedAPIs"
+	CheckUnhealthyPackage = "UnhealthyPackage"
+	CheckPausedResource   = "PausedResource"
+	CheckOversizedSecret  = "OversizedSecret"
+	CheckExternalName     = "ExternalName"
+)
+
+// Severity of a Finding.
+type Severity string
+
+// Finding severities.
+const (
+	SeverityWarning Severity = "Warning"
+	SeverityError   Severity = "Error"
+)
+
+// Crossplane resource categories.
+const (
+	categoryManaged   = "managed"
+	categoryComposite = "composite"
+	categoryClaim     = "claim"
+)
+
+var (
+	crdGVR = schema.GroupVersionResource{
+		Group:    "apiextensions.k8s.io",
+		Version:  "v1",
+		Resource: "customresourcedefinitions",
+	}
+
+	controllerConfigGVR = schema.GroupVersionResource{
+		Group:    "pkg.crossplane.io",
+		Version:  "v1alpha1",
+		Resource: "controllerconfigs",
+	}
+
+	packageGVRs = []schema.GroupVersionResource{
+		{Group: "pkg.crossplane.io", Version: "v1", Resource: "providers"},
+		{Group: "pkg.crossplane.io", Version: "v1", Resource: "configurations"},
+		{Group: "pkg.crossplane.io", Version: "v1beta1", Resource: "functions"},
+	}
+)
+
+// A Finding is a single issue detected by the Doctor along with the steps
+// needed to remediate it.
+type Finding struct {
+	Check       string   `json:"check"`
+	Severity    Severity `json:"severity"`
+	Resource    string   `json:"resource"`
+	Message     string   `json:"message"`
+	Remediation string   `json:"remediation"`
+}
+
+// Doctor inspects a Crossplane control plane for conditions that commonly
+// cause migrations to fail.
+type Doctor struct {
+	dynamic dynamic.Interface
+	kube    kubernetes.Interface
+}
+
+// NewDoctor constructs a Doctor for the cluster of the given clients.
+func NewDoctor(d dynamic.Interface, k kubernetes.Interface) *Doctor {
+	return &Doctor{
+		dynamic: d,
+		kube:    k,
+	}
+}
+
+// Run performs all checks and returns the findings.
+func (d *Doctor) Run(ctx context.Context) ([]Finding, error) {
+	findings := []Finding{}
+	for _, check := range []func(context.Context) ([]Finding, error){
+		d.checkDeprecatedAPIs,
+		d.checkPackages,
+		d.checkResources,
+		d.checkSecrets,
+	} {
+		f, err := check(ctx)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, f...)
+	}
+	return findings, nil
+}
+
+func (d *Doctor) checkDeprecatedAPIs(ctx context.Context) ([]Finding, error) {
+	l, err := d.dynamic.Resource(controllerConfigGVR).List(ctx, metav1.ListOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtList, controllerConfigGVR.GroupResource())
+	}
+	findings := make([]Finding, 0, len(l.Items))
+	for _, cc := range l.Items {
+		findings = append(findings, Finding{
+			Check:       CheckDeprecatedAPIs,
+			Severity:    SeverityWarning,
+			Resource:    resourceName(controllerConfigGVR.GroupResource(), &cc),
+			Message:     "ControllerConfig is deprecated",
+			Remediation: "Replace the ControllerConfig with a DeploymentRuntimeConfig and update the runtimeConfigRef of the packages that use it.",
+		})
+	}
+	return findings, nil
+}
+
+func (d *Doctor) checkPackages(ctx context.Context) ([]Finding, error) {
+	findings := []Finding{}
+	for _, gvr := range packageGVRs {
+		l, err := d.dynamic.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtList, gvr.GroupResource())
+		}
+		for _, u := range l.Items {
+			pkg := resources.Package{Unstructured: u}
+			if pkg.GetInstalled() && pkg.GetHealthy() {
+				continue
+			}
+			findings = append(findings, Finding{
+				Check:       CheckUnhealthyPackage,
+				Severity:    SeverityError,
+				Resource:    resourceName(gvr.GroupResource(), &u),
+				Message:     "package is not installed and healthy",
+				Remediation: "Inspect the package and its revisions with kubectl describe and resolve the reported conditions before exporting.",
+			})
+		}
+	}
+	return findings, nil
+}
+
+func (d *Doctor) checkResources(ctx context.Context) ([]Finding, error) {
+	crds, err := d.dynamic.Resource(crdGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListCRDs)
+	}
+	findings := []Finding{}
+	for _, crd := range crds.Items {
+		categories := CRDCategories(&crd)
+		if !categories[categoryManaged] && !categories[categoryComposite] && !categories[categoryClaim] {
+			continue
+		}
+		gvr, ok := StorageGVR(&crd)
+		if !ok {
+			continue
+		}
+		l, err := d.dynamic.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtList, gvr.GroupResource())
+		}
+		for i := range l.Items {
+			u := &l.Items[i]
+			if meta.IsPaused(u) {
+				findings = append(findings, Finding{
+					Check:       CheckPausedResource,
+					Severity:    SeverityWarning,
+					Resource:    resourceName(gvr.GroupResource(), u),
+					Message:     fmt.Sprintf("resource has the %s annotation", meta.AnnotationKeyReconciliationPaused),
+					Remediation: "Confirm the resource is intentionally paused; it will remain paused after the migration.",
+				})
+			}
+			if categories[categoryManaged] && isReady(u) && meta.GetExternalName(u) == "" {
+				findings = append(findings, Finding{
+					Check:       CheckExternalName,
+					Severity:    SeverityError,
+					Resource:    resourceName(gvr.GroupResource(), u),
+					Message:     fmt.Sprintf("ready managed resource has no %s annotation", meta.AnnotationKeyExternalName),
+					Remediation: "Wait for the provider to record the external name, or set it manually, to avoid the external resource being recreated.",
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+func (d *Doctor) checkSecrets(ctx context.Context) ([]Finding, error) {
+	l, err := d.kube.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListSecrets)
+	}
+	findings := []Finding{}
+	for _, s := range l.Items {
+		size := 0
+		for _, v := range s.Data {
+			size += len(v)
+		}
+		if size <= MaxSecretSize {
+			continue
+		}
+		findings = append(findings, Finding{
+			Check:       CheckOversizedSecret,
+			Severity:    SeverityWarning,
+			Resource:    fmt.Sprintf("secrets/%s/%s", s.GetNamespace(), s.GetName()),
+			Message:     fmt.Sprintf("secret data is %d bytes", size),
+			Remediation: "Reduce the size of the secret or exclude it from the export and recreate it in the target.",
+		})
+	}
+	return findings, nil
+}
+
+// CRDCategories returns the set of categories of the supplied
+// CustomResourceDefinition.
+func CRDCategories(crd *unstructured.Unstructured) map[string]bool {
+	categories, _, _ := unstructured.NestedStringSlice(crd.Object, "spec", "names", "categories")
+	set := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		set[c] = true
+	}
+	return set
+}
+
+// StorageGVR returns the GroupVersionResource of the storage version of the
+// supplied CustomResourceDefinition.
+func StorageGVR(crd *unstructured.Unstructured) (schema.GroupVersionResource, bool) {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if storage, _ := m["storage"].(bool); !storage {
+			continue
+		}
+		name, _ := m["name"].(string)
+		return schema.GroupVersionResource{Group: group, Version: name, Resource: plural}, true
+	}
+	return schema.GroupVersionResource{}, false
+}
+
+func isReady(u *unstructured.Unstructured) bool {
+	conditioned := xpv1.ConditionedStatus{}
+	if err := fieldpath.Pave(u.Object).GetValueInto("status", &conditioned); err != nil {
+		return false
+	}
+	return resource.IsConditionTrue(conditioned.GetCondition(xpv1.TypeReady))
+}
+
+func resourceName(gr schema.GroupResource, u *unstructured.Unstructured) string {
+	if u.GetNamespace() != "" {
+		return fmt.Sprintf("%s/%s/%s", gr.String(), u.GetNamespace(), u.GetName())
+	}
+	return fmt.Sprintf("%s/%s", gr.String(), u.GetName())
+}
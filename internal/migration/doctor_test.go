@@ -0,0 +1,156 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+var bucketGVR = schema.GroupVersionResource{Group: "s3.aws.upbound.io", Version: "v1beta1", Resource: "buckets"}
+
+func listKinds() map[schema.GroupVersionResource]string {
+	kinds := map[schema.GroupVersionResource]string{
+		crdGVR:              "CustomResourceDefinitionList",
+		controllerConfigGVR: "ControllerConfigList",
+		bucketGVR:           "BucketList",
+	}
+	for _, gvr := range packageGVRs {
+		kinds[gvr] = "PackageList"
+	}
+	return kinds
+}
+
+func bucketCRD() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "buckets.s3.aws.upbound.io"},
+		"spec": map[string]any{
+			"group": "s3.aws.upbound.io",
+			"names": map[string]any{
+				"plural":     "buckets",
+				"categories": []any{"crossplane", "managed", "aws"},
+			},
+			"versions": []any{
+				map[string]any{"name": "v1beta1", "storage": true},
+			},
+		},
+	}}
+}
+
+func bucket(name string, annotations map[string]any, ready bool) *unstructured.Unstructured {
+	status := "False"
+	if ready {
+		status = "True"
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind":       "Bucket",
+		"metadata": map[string]any{
+			"name":        name,
+			"annotations": annotations,
+		},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": status},
+			},
+		},
+	}}
+}
+
+func TestDoctorRun(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		objs    []runtime.Object
+		secrets []runtime.Object
+		want    []string
+	}{
+		"Healthy": {
+			reason: "A control plane without issues should not have findings.",
+			objs: []runtime.Object{
+				bucketCRD(),
+				bucket("ok", map[string]any{"crossplane.io/external-name": "ok"}, true),
+			},
+			want: []string{},
+		},
+		"AllChecks": {
+			reason: "Each detected issue should be reported.",
+			objs: []runtime.Object{
+				bucketCRD(),
+				bucket("paused", map[string]any{"crossplane.io/paused": "true", "crossplane.io/external-name": "paused"}, true),
+				bucket("no-name", nil, true),
+				bucket("creating", nil, false),
+				&unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "pkg.crossplane.io/v1alpha1",
+					"kind":       "ControllerConfig",
+					"metadata":   map[string]any{"name": "debug"},
+				}},
+				&unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "pkg.crossplane.io/v1",
+					"kind":       "Provider",
+					"metadata":   map[string]any{"name": "provider-aws"},
+				}},
+			},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "crossplane-system"},
+					Data:       map[string][]byte{"data": make([]byte, MaxSecretSize+1)},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "crossplane-system"},
+					Data:       map[string][]byte{"data": []byte("hello")},
+				},
+			},
+			want: []string{
+				CheckDeprecatedAPIs + " controllerconfigs.pkg.crossplane.io/debug",
+				CheckUnhealthyPackage + " providers.pkg.crossplane.io/provider-aws",
+				CheckPausedResource + " buckets.s3.aws.upbound.io/paused",
+				CheckExternalName + " buckets.s3.aws.upbound.io/no-name",
+				CheckOversizedSecret + " secrets/crossplane-system/big",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := NewDoctor(
+				dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds(), tc.objs...),
+				kubefake.NewSimpleClientset(tc.secrets...),
+			)
+			findings, err := d.Run(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\nRun(...): unexpected error: %v", tc.reason, err)
+			}
+			got := make([]string, len(findings))
+			for i, f := range findings {
+				got[i] = f.Check + " " + f.Resource
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	defaultVerifyInterval = 5 * time.Second
+
+	errNotAllReady = "not all resources became ready"
+)
+
+// DefaultVerifyCategories are the categories of the resources verified after
+// a migration has been activated.
+var DefaultVerifyCategories = []string{categoryClaim, categoryComposite}
+
+// An UnreadyResource is a resource that does not report Ready=True.
+type UnreadyResource struct {
+	Resource string `json:"resource"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+}
+
+// A Verifier waits for the resources of a set of categories to become ready.
+type Verifier struct {
+	dynamic    dynamic.Interface
+	categories []string
+	interval   time.Duration
+}
+
+// VerifierOption modifies a Verifier.
+type VerifierOption func(*Verifier)
+
+// WithCategories sets the categories of the resources that are verified.
+func WithCategories(c ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.categories = c
+	}
+}
+
+// WithPollInterval sets the interval at which resources are checked.
+func WithPollInterval(i time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.interval = i
+	}
+}
+
+// NewVerifier constructs a Verifier for the cluster of the given client.
+func NewVerifier(d dynamic.Interface, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		dynamic:    d,
+		categories: DefaultVerifyCategories,
+		interval:   defaultVerifyInterval,
+	}
+	for _, o := range opts {
+		o(v)
+	}
+	return v
+}
+
+// Wait waits until all resources of the configured categories are ready, or
+// the supplied timeout expires. It returns the number of verified resources
+// and those that were not ready when it returned.
+func (v *Verifier) Wait(ctx context.Context, timeout time.Duration) (int, []UnreadyResource, error) {
+	var total int
+	var unready []UnreadyResource
+	err := wait.PollUntilContextTimeout(ctx, v.interval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		total, unready, err = v.Check(ctx)
+		if err != nil {
+			return false, err
+		}
+		return len(unready) == 0, nil
+	})
+	if len(unready) > 0 {
+		return total, unready, errors.New(errNotAllReady)
+	}
+	return total, unready, err
+}
+
+// Check returns the number of resources of the configured categories and
+// those that are not ready.
+func (v *Verifier) Check(ctx context.Context) (int, []UnreadyResource, error) {
+	crds, err := v.dynamic.Resource(crdGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errListCRDs)
+	}
+	total := 0
+	unready := []UnreadyResource{}
+	for _, crd := range crds.Items {
+		if !hasAnyCategory(CRDCategories(&crd), v.categories) {
+			continue
+		}
+		gvr, ok := StorageGVR(&crd)
+		if !ok {
+			continue
+		}
+		l, err := v.dynamic.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return 0, nil, errors.Wrapf(err, errFmtList, gvr.GroupResource())
+		}
+		for i := range l.Items {
+			u := &l.Items[i]
+			total++
+			if isReady(u) {
+				continue
+			}
+			conditioned := xpv1.ConditionedStatus{}
+			_ = fieldpath.Pave(u.Object).GetValueInto("status", &conditioned)
+			c := conditioned.GetCondition(xpv1.TypeReady)
+			unready = append(unready, UnreadyResource{
+				Resource: resourceName(gvr.GroupResource(), u),
+				Reason:   string(c.Reason),
+				Message:  c.Message,
+			})
+		}
+	}
+	return total, unready, nil
+}
+
+func hasAnyCategory(set map[string]bool, categories []string) bool {
+	for _, c := range categories {
+		if set[c] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestVerifierCheck(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		categories []string
+		objs       []runtime.Object
+		wantTotal  int
+		want       []string
+	}{
+		"OtherCategory": {
+			reason:     "Resources of categories that are not verified should be ignored.",
+			categories: DefaultVerifyCategories,
+			objs:       []runtime.Object{bucketCRD(), bucket("creating", nil, false)},
+			want:       []string{},
+		},
+		"Unready": {
+			reason:     "Resources of verified categories that are not ready should be reported.",
+			categories: []string{categoryManaged},
+			objs: []runtime.Object{
+				bucketCRD(),
+				bucket("ready", nil, true),
+				bucket("creating", nil, false),
+			},
+			wantTotal: 2,
+			want:      []string{"buckets.s3.aws.upbound.io/creating"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewVerifier(
+				dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds(), tc.objs...),
+				WithCategories(tc.categories...),
+			)
+			total, unready, err := v.Check(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\nCheck(...): unexpected error: %v", tc.reason, err)
+			}
+			got := make([]string, len(unready))
+			for i, u := range unready {
+				got[i] = u.Resource
+			}
+			if diff := cmp.Diff(tc.wantTotal, total); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want total, +got total:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin discovers and runs executables that extend up with
+// additional subcommands, in the style of kubectl plugins.
+package plugin
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/upbound/up/internal/config"
+)
+
+// Prefix is the prefix of the name of plugin executables. An executable named
+// up-foo on the PATH is run as "up foo".
+const Prefix = "up-"
+
+const (
+	envProfile     = "UP_PROFILE"
+	envAccount     = "UP_ACCOUNT"
+	envKubeContext = "UP_KUBE_CONTEXT"
+	envGroup       = "UP_GROUP"
+)
+
+// A Plugin is an executable that provides an up subcommand.
+type Plugin struct {
+	// Name is the subcommand the plugin provides.
+	Name string `json:"name"`
+	// Path is the path of the executable.
+	Path string `json:"path"`
+	// Shadowed is true if the plugin is hidden by a plugin of the same name
+	// earlier on the PATH.
+	Shadowed bool `json:"shadowed"`
+}
+
+// A Flag is a flag of up that may be given before the name of a plugin. Its
+// value is passed to the plugin in the environment variable Env.
+type Flag struct {
+	Env  string
+	Bool bool
+}
+
+// Split splits the supplied arguments of up into the name of a plugin, the
+// arguments passed to the plugin and the environment variables set by the
+// flags given before the name. Flags are keyed by their name on the command
+// line, e.g. "--profile" or "-a". It returns false if there is no name, or if
+// a flag before the name is not one of the supplied flags.
+func Split(args []string, flags map[string]Flag) (name string, pargs, env []string, ok bool) {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			return args[i], args[i+1:], env, true
+		}
+		k, v, hasValue := strings.Cut(args[i], "=")
+		f, known := flags[k]
+		switch {
+		case !known:
+			return "", nil, nil, false
+		case hasValue:
+		case f.Bool:
+			v = "true"
+		case i+1 < len(args):
+			i++
+			v = args[i]
+		default:
+			return "", nil, nil, false
+		}
+		env = append(env, f.Env+"="+v)
+	}
+	return "", nil, nil, false
+}
+
+// Find returns the path of the executable of the plugin with the supplied
+// name.
+func Find(name string) (string, error) {
+	return exec.LookPath(Prefix + name)
+}
+
+// List returns the plugins in the directories of the supplied PATH, in the
+// order of the PATH.
+func List(path string) []Plugin {
+	plugins := []Plugin{}
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		names := []string{}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasPrefix(e.Name(), Prefix) || !isExecutable(filepath.Join(dir, e.Name())) {
+				continue
+			}
+			names = append(names, e.Name())
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			name := strings.TrimPrefix(n, Prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, n), Shadowed: seen[name]})
+			seen[name] = true
+		}
+	}
+	return plugins
+}
+
+// Env returns the environment a plugin is run with: the supplied environment
+// with UP_PROFILE and UP_ACCOUNT set to the profile and account in use, and
+// UP_KUBE_CONTEXT and UP_GROUP set to the current context of the supplied
+// kubeconfig and its namespace, unless they are set already.
+func Env(environ []string, cfg *config.Config, kubeconfig *api.Config) []string {
+	env := append([]string{}, environ...)
+	set := func(k, v string) {
+		if _, ok := lookup(environ, k); !ok && v != "" {
+			env = append(env, k+"="+v)
+		}
+	}
+	if name, p, ok := profile(environ, cfg); ok {
+		set(envProfile, name)
+		set(envAccount, p.Account)
+	}
+	if kubeconfig != nil {
+		if c, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]; ok {
+			set(envKubeContext, kubeconfig.CurrentContext)
+			set(envGroup, c.Namespace)
+		}
+	}
+	return env
+}
+
+// profile returns the profile selected by UP_PROFILE in the supplied
+// environment, or the default profile of the supplied config.
+func profile(environ []string, cfg *config.Config) (string, config.Profile, bool) {
+	if cfg == nil {
+		return "", config.Profile{}, false
+	}
+	if name, ok := lookup(environ, envProfile); ok {
+		p, err := cfg.GetUpboundProfile(name)
+		return name, p, err == nil
+	}
+	name, p, err := cfg.GetDefaultUpboundProfile()
+	return name, p, err == nil
+}
+
+// lookup returns the value of the supplied key in the supplied environment.
+// Like exec.Cmd, it uses the last value if the key is set more than once.
+func lookup(environ []string, key string) (string, bool) {
+	for i := len(environ) - 1; i >= 0; i-- {
+		if k, v, ok := strings.Cut(environ[i], "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return fi.Mode()&0111 != 0
+}
+
+// Run runs the plugin executable at the supplied path with the supplied
+// arguments and environment, connected to the standard streams of up. It
+// returns the exit code of the plugin.
+func Run(path string, args, env []string) (int, error) {
+	cmd := exec.Command(path, args...) //nolint:gosec // Running the plugin chosen by the user is the purpose of this function.
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = env
+	err := cmd.Run()
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return ee.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
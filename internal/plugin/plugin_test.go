@@ -0,0 +1,192 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/upbound/up/internal/config"
+)
+
+func TestList(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	for _, f := range []struct {
+		path string
+		mode os.FileMode
+	}{
+		{filepath.Join(dirA, "up-foo"), 0755},
+		{filepath.Join(dirA, "up-not-executable"), 0644},
+		{filepath.Join(dirA, "kubectl-foo"), 0755},
+		{filepath.Join(dirB, "up-bar"), 0755},
+		{filepath.Join(dirB, "up-foo"), 0755},
+	} {
+		if err := os.WriteFile(f.path, nil, f.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []Plugin{
+		{Name: "foo", Path: filepath.Join(dirA, "up-foo")},
+		{Name: "bar", Path: filepath.Join(dirB, "up-bar")},
+		{Name: "foo", Path: filepath.Join(dirB, "up-foo"), Shadowed: true},
+	}
+	got := List(dirA + string(filepath.ListSeparator) + dirB)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	flags := map[string]Flag{
+		"--profile":   {Env: "UP_PROFILE"},
+		"--account":   {Env: "UP_ACCOUNT"},
+		"-a":          {Env: "UP_ACCOUNT"},
+		"--read-only": {Env: "UP_READ_ONLY", Bool: true},
+	}
+
+	type want struct {
+		name string
+		args []string
+		env  []string
+		ok   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   []string
+		want   want
+	}{
+		"NameFirst": {
+			reason: "The first argument should be the name if it is not a flag.",
+			args:   []string{"foo", "--profile", "x"},
+			want:   want{name: "foo", args: []string{"--profile", "x"}, ok: true},
+		},
+		"LeadingFlags": {
+			reason: "Known flags before the name should be skipped and passed in the environment.",
+			args:   []string{"--profile", "x", "-a=org", "--read-only", "foo", "bar"},
+			want: want{
+				name: "foo",
+				args: []string{"bar"},
+				env:  []string{"UP_PROFILE=x", "UP_ACCOUNT=org", "UP_READ_ONLY=true"},
+				ok:   true,
+			},
+		},
+		"UnknownFlag": {
+			reason: "An unknown flag before the name should not be skipped.",
+			args:   []string{"--format", "json", "foo"},
+			want:   want{},
+		},
+		"MissingValue": {
+			reason: "A flag without its value should not be skipped.",
+			args:   []string{"--profile"},
+			want:   want{},
+		},
+		"NoName": {
+			reason: "There should be no name if there are only flags.",
+			args:   []string{"--read-only"},
+			want:   want{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n, args, env, ok := Split(tc.args, flags)
+			if diff := cmp.Diff(tc.want, want{name: n, args: args, env: env, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nSplit(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnv(t *testing.T) {
+	cfg := &config.Config{
+		Upbound: config.Upbound{
+			Default: "default",
+			Profiles: map[string]config.Profile{
+				"default": {ID: "cool-user", Type: config.UserProfileType, Account: "cool-org"},
+				"other":   {ID: "cool-user", Type: config.UserProfileType, Account: "other-org"},
+			},
+		},
+	}
+
+	kubeconfig := &api.Config{
+		CurrentContext: "space",
+		Contexts: map[string]*api.Context{
+			"space": {Cluster: "space", Namespace: "team-a"},
+		},
+	}
+
+	cases := map[string]struct {
+		reason     string
+		environ    []string
+		cfg        *config.Config
+		kubeconfig *api.Config
+		want       []string
+	}{
+		"NoConfig": {
+			reason:  "The environment should be passed as is if there is no config.",
+			environ: []string{"HOME=/home/user"},
+			want:    []string{"HOME=/home/user"},
+		},
+		"DefaultProfile": {
+			reason:  "The default profile and its account should be added.",
+			environ: []string{"HOME=/home/user"},
+			cfg:     cfg,
+			want:    []string{"HOME=/home/user", "UP_PROFILE=default", "UP_ACCOUNT=cool-org"},
+		},
+		"ProfileFromEnvironment": {
+			reason:  "The account of a profile selected in the environment should be added.",
+			environ: []string{"UP_PROFILE=other"},
+			cfg:     cfg,
+			want:    []string{"UP_PROFILE=other", "UP_ACCOUNT=other-org"},
+		},
+		"AccountFromEnvironment": {
+			reason:  "An account set in the environment should not be overridden.",
+			environ: []string{"UP_ACCOUNT=mine"},
+			cfg:     cfg,
+			want:    []string{"UP_ACCOUNT=mine", "UP_PROFILE=default"},
+		},
+		"ProfileFromFlag": {
+			reason:  "The last UP_PROFILE in the environment, as set by --profile, should select the profile.",
+			environ: []string{"UP_PROFILE=default", "UP_PROFILE=other"},
+			cfg:     cfg,
+			want:    []string{"UP_PROFILE=default", "UP_PROFILE=other", "UP_ACCOUNT=other-org"},
+		},
+		"KubeContext": {
+			reason:     "The current kubeconfig context and its namespace should be added.",
+			environ:    []string{"HOME=/home/user"},
+			kubeconfig: kubeconfig,
+			want:       []string{"HOME=/home/user", "UP_KUBE_CONTEXT=space", "UP_GROUP=team-a"},
+		},
+		"GroupFromEnvironment": {
+			reason:     "A group set in the environment should not be overridden.",
+			environ:    []string{"UP_GROUP=team-b"},
+			kubeconfig: kubeconfig,
+			want:       []string{"UP_GROUP=team-b", "UP_KUBE_CONTEXT=space"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Env(tc.environ, tc.cfg, tc.kubeconfig)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEnv(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
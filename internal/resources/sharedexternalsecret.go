@@ -0,0 +1,89 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// SharedExternalSecretGVK is the GroupVersionKind of a Spaces
+	// SharedExternalSecret.
+	SharedExternalSecretGVK = schema.GroupVersionKind{
+		Group:   "spaces.upbound.io",
+		Version: "v1alpha1",
+		Kind:    "SharedExternalSecret",
+	}
+	// SharedExternalSecretGVR is the GroupVersionResource of a Spaces
+	// SharedExternalSecret.
+	SharedExternalSecretGVR = schema.GroupVersionResource{
+		Group:    "spaces.upbound.io",
+		Version:  "v1alpha1",
+		Resource: "sharedexternalsecrets",
+	}
+)
+
+// SharedExternalSecret represents the Spaces SharedExternalSecret
+// CustomResource and extends an unstructured.Unstructured.
+type SharedExternalSecret struct {
+	unstructured.Unstructured
+}
+
+// GetUnstructured returns the underlying *unstructured.Unstructured.
+func (s *SharedExternalSecret) GetUnstructured() *unstructured.Unstructured {
+	return &s.Unstructured
+}
+
+// GetCondition returns the condition for the given xpv1.ConditionType if it
+// exists, otherwise returns nil.
+func (s *SharedExternalSecret) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	conditioned := xpv1.ConditionedStatus{}
+	// The path is directly `status` because conditions are inline.
+	if err := fieldpath.Pave(s.Object).GetValueInto("status", &conditioned); err != nil {
+		return xpv1.Condition{}
+	}
+	return conditioned.GetCondition(ct)
+}
+
+// GetSecretStoreName returns the name of the secret store the secret is
+// read from.
+func (s *SharedExternalSecret) GetSecretStoreName() string {
+	n, _ := fieldpath.Pave(s.Object).GetString("spec.externalSecretSpec.secretStoreRef.name")
+	return n
+}
+
+// GetExternalSecretName returns the name of the secret projected into control
+// planes.
+func (s *SharedExternalSecret) GetExternalSecretName() string {
+	n, _ := fieldpath.Pave(s.Object).GetString("spec.externalSecretName")
+	return n
+}
+
+// SetControlPlaneSelector sets the control planes the secret is projected
+// into.
+func (s *SharedExternalSecret) SetControlPlaneSelector(names []string, sel *metav1.LabelSelector) {
+	cps := map[string]any{}
+	if len(names) > 0 {
+		cps["names"] = names
+	}
+	if sel != nil {
+		cps["labelSelectors"] = []any{sel}
+	}
+	_ = fieldpath.Pave(s.Object).SetValue("spec.controlPlaneSelector", cps)
+}
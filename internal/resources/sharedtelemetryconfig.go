@@ -0,0 +1,120 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const otlpExporter = "otlphttp"
+
+var (
+	// SharedTelemetryConfigGVK is the GroupVersionKind of a Spaces
+	// SharedTelemetryConfig.
+	SharedTelemetryConfigGVK = schema.GroupVersionKind{
+		Group:   "observability.spaces.upbound.io",
+		Version: "v1alpha1",
+		Kind:    "SharedTelemetryConfig",
+	}
+	// SharedTelemetryConfigGVR is the GroupVersionResource of a Spaces
+	// SharedTelemetryConfig.
+	SharedTelemetryConfigGVR = schema.GroupVersionResource{
+		Group:    "observability.spaces.upbound.io",
+		Version:  "v1alpha1",
+		Resource: "sharedtelemetryconfigs",
+	}
+)
+
+// SharedTelemetryConfig represents the Spaces SharedTelemetryConfig
+// CustomResource and extends an unstructured.Unstructured.
+type SharedTelemetryConfig struct {
+	unstructured.Unstructured
+}
+
+// GetUnstructured returns the underlying *unstructured.Unstructured.
+func (s *SharedTelemetryConfig) GetUnstructured() *unstructured.Unstructured {
+	return &s.Unstructured
+}
+
+// GetCondition returns the condition for the given xpv1.ConditionType if it
+// exists, otherwise returns nil.
+func (s *SharedTelemetryConfig) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	conditioned := xpv1.ConditionedStatus{}
+	// The path is directly `status` because conditions are inline.
+	if err := fieldpath.Pave(s.Object).GetValueInto("status", &conditioned); err != nil {
+		return xpv1.Condition{}
+	}
+	return conditioned.GetCondition(ct)
+}
+
+// SetControlPlaneSelector sets the control planes telemetry is exported from.
+func (s *SharedTelemetryConfig) SetControlPlaneSelector(names []string, sel *metav1.LabelSelector) {
+	cps := map[string]any{}
+	if len(names) > 0 {
+		cps["names"] = names
+	}
+	if sel != nil {
+		cps["labelSelectors"] = []any{sel}
+	}
+	_ = fieldpath.Pave(s.Object).SetValue("spec.controlPlaneSelector", cps)
+}
+
+// SetOTLPExporter configures the supplied signals to be exported to an OTLP
+// HTTP endpoint.
+func (s *SharedTelemetryConfig) SetOTLPExporter(endpoint string, headers map[string]string, signals []string) {
+	exp := map[string]any{"endpoint": endpoint}
+	if len(headers) > 0 {
+		exp["headers"] = headers
+	}
+	p := fieldpath.Pave(s.Object)
+	_ = p.SetValue("spec.exporters", map[string]any{otlpExporter: exp})
+	pipeline := map[string]any{}
+	for _, sig := range signals {
+		pipeline[sig] = []string{otlpExporter}
+	}
+	_ = p.SetValue("spec.exportPipeline", pipeline)
+}
+
+// GetOTLPEndpoint returns the endpoint of the OTLP HTTP exporter.
+func (s *SharedTelemetryConfig) GetOTLPEndpoint() string {
+	e, _ := fieldpath.Pave(s.Object).GetString("spec.exporters." + otlpExporter + ".endpoint")
+	return e
+}
+
+// GetSelectedControlPlanes returns the control planes selected by the config.
+func (s *SharedTelemetryConfig) GetSelectedControlPlanes() []string {
+	return s.getStringArray("status.selectedControlPlanes")
+}
+
+// GetProvisionedControlPlanes returns the control planes telemetry is being
+// exported from.
+func (s *SharedTelemetryConfig) GetProvisionedControlPlanes() []string {
+	return s.getStringArray("status.provisioned")
+}
+
+// GetFailedControlPlanes returns the control planes the config could not be
+// provisioned in.
+func (s *SharedTelemetryConfig) GetFailedControlPlanes() []string {
+	return s.getStringArray("status.failed")
+}
+
+func (s *SharedTelemetryConfig) getStringArray(path string) []string {
+	v, _ := fieldpath.Pave(s.Object).GetStringArray(path)
+	return v
+}
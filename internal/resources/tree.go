@@ -0,0 +1,49 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GetChildRefs returns references to the resources directly below the given
+// resource in a Crossplane resource tree. For a claim this is the composite
+// resource it is bound to, for a composite resource these are the resources it
+// composes. Resources without children, such as managed resources, return no
+// references.
+func GetChildRefs(u *unstructured.Unstructured) []corev1.ObjectReference {
+	p := fieldpath.Pave(u.Object)
+	refs := []corev1.ObjectReference{}
+
+	// Claims reference their composite resource.
+	ref := corev1.ObjectReference{}
+	if err := p.GetValueInto("spec.resourceRef", &ref); err == nil && ref.Name != "" {
+		refs = append(refs, ref)
+	}
+
+	// Composite resources reference the resources they compose.
+	composed := []corev1.ObjectReference{}
+	if err := p.GetValueInto("spec.resourceRefs", &composed); err == nil {
+		for _, r := range composed {
+			if r.Name == "" {
+				continue
+			}
+			refs = append(refs, r)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetChildRefs(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    map[string]any
+		want   []corev1.ObjectReference
+	}{
+		"ManagedResource": {
+			reason: "A resource without references should not have children.",
+			obj: map[string]any{
+				"spec": map[string]any{
+					"forProvider": map[string]any{},
+				},
+			},
+			want: []corev1.ObjectReference{},
+		},
+		"Claim": {
+			reason: "A claim should return a reference to its composite.",
+			obj: map[string]any{
+				"spec": map[string]any{
+					"resourceRef": map[string]any{
+						"apiVersion": "example.org/v1",
+						"kind":       "XDatabase",
+						"name":       "my-db-abcde",
+					},
+				},
+			},
+			want: []corev1.ObjectReference{
+				{APIVersion: "example.org/v1", Kind: "XDatabase", Name: "my-db-abcde"},
+			},
+		},
+		"Composite": {
+			reason: "A composite should return references to composed resources, skipping unnamed ones.",
+			obj: map[string]any{
+				"spec": map[string]any{
+					"resourceRefs": []any{
+						map[string]any{
+							"apiVersion": "rds.aws.upbound.io/v1beta1",
+							"kind":       "Instance",
+							"name":       "my-db-abcde-12345",
+						},
+						map[string]any{
+							"apiVersion": "rds.aws.upbound.io/v1beta1",
+							"kind":       "SubnetGroup",
+						},
+					},
+				},
+			},
+			want: []corev1.ObjectReference{
+				{APIVersion: "rds.aws.upbound.io/v1beta1", Kind: "Instance", Name: "my-db-abcde-12345"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetChildRefs(&unstructured.Unstructured{Object: tc.obj})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetChildRefs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive exports the Space-level configuration of an Upbound Space to
+// an archive, and imports it back into a Space.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// GroupLabel marks a namespace as a control plane group.
+	GroupLabel = "spaces.upbound.io/group"
+
+	// Version of the archive format.
+	Version = "v1alpha1"
+
+	metaFilename = "space/meta.json"
+	rootDir      = "space"
+	mode         = 0644
+
+	errListGroups      = "unable to list control plane groups"
+	errWriteArchive    = "unable to write archive"
+	errReadArchive     = "unable to read archive"
+	errDecodeMeta      = "unable to decode archive metadata"
+	errNoMeta          = "archive does not contain metadata"
+	errFmtUnknownEntry = "unknown archive entry %s"
+	errFmtVersion      = "unsupported archive version %q"
+	errFmtList         = "unable to list %s"
+	errFmtDecode       = "unable to decode %s"
+	errFmtCreate       = "unable to create %s %s"
+)
+
+var namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// Resources are the namespaced kinds that make up the Space-level
+// configuration of each control plane group. They are imported in order, so
+// that the objects a kind depends on are created before it.
+var Resources = []schema.GroupVersionResource{
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"},
+	{Group: "spaces.upbound.io", Version: "v1alpha1", Resource: "sharedsecretstores"},
+	{Group: "spaces.upbound.io", Version: "v1alpha1", Resource: "sharedexternalsecrets"},
+	{Group: "spaces.upbound.io", Version: "v1alpha1", Resource: "sharedbackupconfigs"},
+	{Group: "spaces.upbound.io", Version: "v1beta1", Resource: "controlplanes"},
+}
+
+// Meta is the metadata of an archive.
+type Meta struct {
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Groups    []string  `json:"groups"`
+}
+
+// Result summarizes an export or import.
+type Result struct {
+	// Counts is the number of objects exported or imported per resource.
+	Counts map[string]int
+	// Skipped are the objects not imported because they already exist.
+	Skipped []string
+}
+
+// Export writes the control plane groups of a Space, and the Space-level
+// objects within them, to the supplied writer as a gzipped tar archive.
+func Export(ctx context.Context, client dynamic.Interface, w io.Writer) (*Result, error) { //nolint:gocyclo
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	res := &Result{Counts: map[string]int{}}
+
+	groups, err := client.Resource(namespaceGVR).List(ctx, metav1.ListOptions{LabelSelector: GroupLabel + "=true"})
+	if err != nil {
+		return nil, errors.Wrap(err, errListGroups)
+	}
+	meta := Meta{Version: Version, CreatedAt: time.Now().UTC(), Groups: []string{}}
+	for i := range groups.Items {
+		g := &groups.Items[i]
+		meta.Groups = append(meta.Groups, g.GetName())
+		if err := writeObject(tw, namespaceGVR, g); err != nil {
+			return nil, errors.Wrap(err, errWriteArchive)
+		}
+		res.Counts[resourceName(namespaceGVR)]++
+	}
+
+	for _, gvr := range Resources {
+		for _, g := range meta.Groups {
+			l, err := client.Resource(gvr).Namespace(g).List(ctx, metav1.ListOptions{})
+			if kerrors.IsNotFound(err) {
+				// The kind is not served by this version of Spaces.
+				break
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtList, resourceName(gvr))
+			}
+			for i := range l.Items {
+				if err := writeObject(tw, gvr, &l.Items[i]); err != nil {
+					return nil, errors.Wrap(err, errWriteArchive)
+				}
+				res.Counts[resourceName(gvr)]++
+			}
+		}
+	}
+
+	b, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, errWriteArchive)
+	}
+	if err := writeFile(tw, metaFilename, b); err != nil {
+		return nil, errors.Wrap(err, errWriteArchive)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, errWriteArchive)
+	}
+	return res, errors.Wrap(gw.Close(), errWriteArchive)
+}
+
+// Import reads an archive written by Export and creates the objects it
+// contains. Objects that already exist in the Space are skipped.
+func Import(ctx context.Context, client dynamic.Interface, r io.Reader) (*Result, error) { //nolint:gocyclo
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadArchive)
+	}
+	defer gr.Close() //nolint:errcheck
+
+	known := map[string]schema.GroupVersionResource{resourceName(namespaceGVR): namespaceGVR}
+	for _, gvr := range Resources {
+		known[resourceName(gvr)] = gvr
+	}
+
+	var meta *Meta
+	objs := map[string][]*unstructured.Unstructured{}
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, errReadArchive)
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrap(err, errReadArchive)
+		}
+		if h.Name == metaFilename {
+			meta = &Meta{}
+			if err := json.Unmarshal(b, meta); err != nil {
+				return nil, errors.Wrap(err, errDecodeMeta)
+			}
+			continue
+		}
+		// Entries are named space/<resource>/[<namespace>/]<name>.yaml.
+		parts := strings.Split(h.Name, "/")
+		if len(parts) < 3 || parts[0] != rootDir {
+			return nil, errors.Errorf(errFmtUnknownEntry, h.Name)
+		}
+		if _, ok := known[parts[1]]; !ok {
+			return nil, errors.Errorf(errFmtUnknownEntry, h.Name)
+		}
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(b, &u.Object); err != nil {
+			return nil, errors.Wrapf(err, errFmtDecode, h.Name)
+		}
+		objs[parts[1]] = append(objs[parts[1]], u)
+	}
+	if meta == nil {
+		return nil, errors.New(errNoMeta)
+	}
+	if meta.Version != Version {
+		return nil, errors.Errorf(errFmtVersion, meta.Version)
+	}
+
+	res := &Result{Counts: map[string]int{}}
+	for _, gvr := range append([]schema.GroupVersionResource{namespaceGVR}, Resources...) {
+		name := resourceName(gvr)
+		for _, u := range objs[name] {
+			ri := client.Resource(gvr).Namespace(u.GetNamespace())
+			if u.GetNamespace() == "" {
+				ri = client.Resource(gvr)
+			}
+			_, err := ri.Create(ctx, u, metav1.CreateOptions{})
+			if kerrors.IsAlreadyExists(err) {
+				res.Skipped = append(res.Skipped, objectPath(gvr, u))
+				continue
+			}
+			if err != nil {
+				return res, errors.Wrapf(err, errFmtCreate, name, u.GetName())
+			}
+			res.Counts[name]++
+		}
+	}
+	return res, nil
+}
+
+// writeObject writes the supplied object to the archive, stripped of the
+// fields that are set by the API server.
+func writeObject(tw *tar.Writer, gvr schema.GroupVersionResource, u *unstructured.Unstructured) error {
+	c := u.DeepCopy()
+	c.SetUID("")
+	c.SetResourceVersion("")
+	c.SetGeneration(0)
+	c.SetCreationTimestamp(metav1.Time{})
+	c.SetManagedFields(nil)
+	c.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(c.Object, "status")
+	b, err := yaml.Marshal(c.Object)
+	if err != nil {
+		return err
+	}
+	return writeFile(tw, objectPath(gvr, c), b)
+}
+
+func writeFile(tw *tar.Writer, name string, b []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: mode,
+		Size: int64(len(b)),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(b)
+	return err
+}
+
+func objectPath(gvr schema.GroupVersionResource, u *unstructured.Unstructured) string {
+	return path.Join(rootDir, resourceName(gvr), u.GetNamespace(), u.GetName()+".yaml")
+}
+
+func resourceName(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return gvr.Resource + "." + gvr.Group
+}
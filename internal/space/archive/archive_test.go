@@ -0,0 +1,96 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var controlPlaneGVR = schema.GroupVersionResource{Group: "spaces.upbound.io", Version: "v1beta1", Resource: "controlplanes"}
+
+func listKinds() map[schema.GroupVersionResource]string {
+	kinds := map[schema.GroupVersionResource]string{namespaceGVR: "NamespaceList"}
+	for _, gvr := range Resources {
+		kinds[gvr] = "List"
+	}
+	return kinds
+}
+
+func object(apiVersion, kind, namespace, name string, labels map[string]any) *unstructured.Unstructured {
+	md := map[string]any{"name": name, "uid": "1234", "resourceVersion": "42"}
+	if namespace != "" {
+		md["namespace"] = namespace
+	}
+	if labels != nil {
+		md["labels"] = labels
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   md,
+		"status":     map[string]any{"ready": true},
+	}}
+}
+
+func TestExportImport(t *testing.T) {
+	src := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds(),
+		object("v1", "Namespace", "", "default", map[string]any{GroupLabel: "true"}),
+		object("v1", "Namespace", "", "kube-system", nil),
+		object("spaces.upbound.io/v1beta1", "ControlPlane", "default", "ctp1", nil),
+		object("spaces.upbound.io/v1beta1", "ControlPlane", "kube-system", "ignored", nil),
+		object("rbac.authorization.k8s.io/v1", "RoleBinding", "default", "admins", nil),
+	)
+	buf := &bytes.Buffer{}
+	exp, err := Export(context.Background(), src, buf)
+	if err != nil {
+		t.Fatalf("Export(...): %v", err)
+	}
+	want := map[string]int{
+		"namespaces":                             1,
+		"controlplanes.spaces.upbound.io":        1,
+		"rolebindings.rbac.authorization.k8s.io": 1,
+	}
+	if diff := cmp.Diff(want, exp.Counts); diff != "" {
+		t.Errorf("Export(...): -want counts, +got counts:\n%s", diff)
+	}
+
+	// The target Space already has the default group.
+	dst := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds(),
+		object("v1", "Namespace", "", "default", map[string]any{GroupLabel: "true"}),
+	)
+	imp, err := Import(context.Background(), dst, buf)
+	if err != nil {
+		t.Fatalf("Import(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"space/namespaces/default.yaml"}, imp.Skipped); diff != "" {
+		t.Errorf("Import(...): -want skipped, +got skipped:\n%s", diff)
+	}
+	got, err := dst.Resource(controlPlaneGVR).Namespace("default").Get(context.Background(), "ctp1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if got.GetUID() != "" || got.Object["status"] != nil {
+		t.Errorf("Import(...): server populated fields were not stripped: %v", got.Object)
+	}
+}
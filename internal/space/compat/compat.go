@@ -0,0 +1,91 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compat describes which Crossplane versions the hosted control planes
+// of an Upbound Spaces version support.
+package compat
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	errFmtParseVersion = "unable to parse version %q"
+)
+
+// Range is an inclusive range of Crossplane minor versions.
+type Range struct {
+	min minor
+	max minor
+}
+
+type minor struct {
+	Major int64
+	Minor int64
+}
+
+func (m minor) less(o minor) bool {
+	return m.Major < o.Major || (m.Major == o.Major && m.Minor < o.Minor)
+}
+
+func (m minor) String() string {
+	return fmt.Sprintf("%d.%d", m.Major, m.Minor)
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%s - %s", r.min, r.max)
+}
+
+// spaces maps minor versions of Spaces to the Crossplane minor versions their
+// hosted control planes support. It must be updated with each Spaces release.
+var spaces = map[minor]Range{
+	{1, 0}: {min: minor{1, 12}, max: minor{1, 14}},
+	{1, 1}: {min: minor{1, 13}, max: minor{1, 14}},
+	{1, 2}: {min: minor{1, 13}, max: minor{1, 15}},
+	{1, 3}: {min: minor{1, 14}, max: minor{1, 15}},
+	{1, 4}: {min: minor{1, 14}, max: minor{1, 16}},
+}
+
+// CrossplaneRange returns the Crossplane versions supported by the hosted
+// control planes of the supplied Spaces version. It returns false if the
+// Spaces version is not known.
+func CrossplaneRange(spacesVersion string) (Range, bool, error) {
+	m, err := parseMinor(spacesVersion)
+	if err != nil {
+		return Range{}, false, err
+	}
+	r, ok := spaces[m]
+	return r, ok, nil
+}
+
+// Contains returns true if the supplied Crossplane or UXP version, such as
+// 1.14.1 or v1.14.1-up.1, is within the range.
+func (r Range) Contains(version string) (bool, error) {
+	m, err := parseMinor(version)
+	if err != nil {
+		return false, err
+	}
+	return !m.less(r.min) && !r.max.less(m), nil
+}
+
+func parseMinor(version string) (minor, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return minor{}, errors.Wrapf(err, errFmtParseVersion, version)
+	}
+	return minor{Major: v.Major(), Minor: v.Minor()}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compat
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestContains(t *testing.T) {
+	type want struct {
+		known    bool
+		contains bool
+	}
+	cases := map[string]struct {
+		reason     string
+		spaces     string
+		crossplane string
+		want       want
+	}{
+		"UnknownSpacesVersion": {
+			reason:     "An unknown Spaces version should not be known.",
+			spaces:     "0.9.0",
+			crossplane: "1.14.1",
+		},
+		"Supported": {
+			reason:     "A Crossplane version within the range should be supported, regardless of patch version.",
+			spaces:     "v1.2.3",
+			crossplane: "1.15.0",
+			want:       want{known: true, contains: true},
+		},
+		"SupportedUXP": {
+			reason:     "A UXP version within the range should be supported.",
+			spaces:     "1.2.0",
+			crossplane: "v1.13.2-up.2",
+			want:       want{known: true, contains: true},
+		},
+		"TooOld": {
+			reason:     "A Crossplane version older than the range should not be supported.",
+			spaces:     "1.3.0",
+			crossplane: "1.13.2",
+			want:       want{known: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, known, err := CrossplaneRange(tc.spaces)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := want{known: known}
+			if known {
+				got.contains, err = r.Contains(tc.crossplane)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nContains(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
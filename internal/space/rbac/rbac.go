@@ -0,0 +1,164 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rbac evaluates the Kubernetes RBAC grants of an Upbound Space.
+package rbac
+
+import (
+	"context"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	kindRole        = "Role"
+	kindClusterRole = "ClusterRole"
+
+	errListRoleBindings        = "unable to list role bindings"
+	errListClusterRoleBindings = "unable to list cluster role bindings"
+	errFmtGetRole              = "unable to get %s %s"
+)
+
+// A Grant is a chain of RBAC objects that allows a subject to perform a verb
+// on a resource.
+type Grant struct {
+	Subject rbacv1.Subject
+	// Binding is the RoleBinding or ClusterRoleBinding that binds the role to
+	// the subject, e.g. RoleBinding/default/admins.
+	Binding string
+	// Role is the Role or ClusterRole that allows the verb, e.g.
+	// ClusterRole/spaces-admin.
+	Role string
+}
+
+// WhoCan returns the grants that allow subjects to perform the supplied verb
+// on the supplied resource in the supplied namespace. Grants of
+// ClusterRoleBindings are included, as they apply to all namespaces.
+func WhoCan(ctx context.Context, client kubernetes.Interface, namespace, verb string, gr schema.GroupResource) ([]Grant, error) { //nolint:gocyclo
+	grants := []Grant{}
+	rules := map[string][]rbacv1.PolicyRule{}
+	allows := func(ref rbacv1.RoleRef, ns string) (bool, error) {
+		key := ref.Kind + "/" + ref.Name
+		if ref.Kind == kindRole {
+			key = ref.Kind + "/" + ns + "/" + ref.Name
+		}
+		rs, ok := rules[key]
+		if !ok {
+			var err error
+			if rs, err = roleRules(ctx, client, ref, ns); err != nil {
+				return false, err
+			}
+			rules[key] = rs
+		}
+		for _, r := range rs {
+			if RuleAllows(r, verb, gr) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	rbs, err := client.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListRoleBindings)
+	}
+	for _, rb := range rbs.Items {
+		ok, err := allows(rb.RoleRef, rb.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, s := range rb.Subjects {
+			grants = append(grants, Grant{Subject: s, Binding: "RoleBinding/" + rb.Namespace + "/" + rb.Name, Role: roleName(rb.RoleRef, rb.Namespace)})
+		}
+	}
+
+	crbs, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, errListClusterRoleBindings)
+	}
+	for _, crb := range crbs.Items {
+		ok, err := allows(crb.RoleRef, "")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, s := range crb.Subjects {
+			grants = append(grants, Grant{Subject: s, Binding: "ClusterRoleBinding/" + crb.Name, Role: roleName(crb.RoleRef, "")})
+		}
+	}
+
+	sort.SliceStable(grants, func(i, j int) bool {
+		if grants[i].Subject.Kind != grants[j].Subject.Kind {
+			return grants[i].Subject.Kind < grants[j].Subject.Kind
+		}
+		return grants[i].Subject.Name < grants[j].Subject.Name
+	})
+	return grants, nil
+}
+
+// roleRules returns the rules of the referenced role. A role that does not
+// exist has no rules.
+func roleRules(ctx context.Context, client kubernetes.Interface, ref rbacv1.RoleRef, ns string) ([]rbacv1.PolicyRule, error) {
+	if ref.Kind == kindRole {
+		r, err := client.RbacV1().Roles(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGetRole, ref.Kind, ref.Name)
+		}
+		return r.Rules, nil
+	}
+	r, err := client.RbacV1().ClusterRoles().Get(ctx, ref.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtGetRole, ref.Kind, ref.Name)
+	}
+	return r.Rules, nil
+}
+
+func roleName(ref rbacv1.RoleRef, ns string) string {
+	if ref.Kind == kindClusterRole || ns == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+	return ref.Kind + "/" + ns + "/" + ref.Name
+}
+
+// RuleAllows returns true if the supplied rule allows the supplied verb on the
+// supplied resource.
+func RuleAllows(r rbacv1.PolicyRule, verb string, gr schema.GroupResource) bool {
+	return matches(r.Verbs, verb) && matches(r.APIGroups, gr.Group) && matches(r.Resources, gr.Resource)
+}
+
+func matches(values []string, want string) bool {
+	for _, v := range values {
+		if v == rbacv1.VerbAll || v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var controlPlanes = schema.GroupResource{Group: "spaces.upbound.io", Resource: "controlplanes"}
+
+func TestRuleAllows(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		rule   rbacv1.PolicyRule
+		verb   string
+		want   bool
+	}{
+		"Exact": {
+			reason: "A rule naming the verb, group and resource should allow it.",
+			rule:   rbacv1.PolicyRule{Verbs: []string{"get", "create"}, APIGroups: []string{"spaces.upbound.io"}, Resources: []string{"controlplanes"}},
+			verb:   "create",
+			want:   true,
+		},
+		"Wildcard": {
+			reason: "A rule with wildcards should allow any verb, group and resource.",
+			rule:   rbacv1.PolicyRule{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+			verb:   "delete",
+			want:   true,
+		},
+		"OtherVerb": {
+			reason: "A rule not naming the verb should not allow it.",
+			rule:   rbacv1.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{"spaces.upbound.io"}, Resources: []string{"controlplanes"}},
+			verb:   "delete",
+			want:   false,
+		},
+		"OtherGroup": {
+			reason: "A rule for a resource of the same name in another group should not allow it.",
+			rule:   rbacv1.PolicyRule{Verbs: []string{"*"}, APIGroups: []string{""}, Resources: []string{"controlplanes"}},
+			verb:   "get",
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RuleAllows(tc.rule, tc.verb, controlPlanes)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRuleAllows(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWhoCan(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "editor"},
+			Rules:      []rbacv1.PolicyRule{{Verbs: []string{"create"}, APIGroups: []string{"spaces.upbound.io"}, Resources: []string{"controlplanes"}}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "editors"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "editor"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "jane"}},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+			Rules:      []rbacv1.PolicyRule{{Verbs: []string{"get"}, APIGroups: []string{"*"}, Resources: []string{"*"}}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewers"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "viewer"},
+			Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "auditors"}},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+			Rules:      []rbacv1.PolicyRule{{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			Subjects:   []rbacv1.Subject{{Kind: "User", Name: "joe"}},
+		},
+	)
+
+	cases := map[string]struct {
+		reason string
+		verb   string
+		want   []Grant
+	}{
+		"Create": {
+			reason: "Only the subject bound to a role of the group allowing create should be returned.",
+			verb:   "create",
+			want: []Grant{
+				{Subject: rbacv1.Subject{Kind: "User", Name: "jane"}, Binding: "RoleBinding/team-a/editors", Role: "Role/team-a/editor"},
+			},
+		},
+		"Get": {
+			reason: "Subjects of ClusterRoleBindings should be returned for every group.",
+			verb:   "get",
+			want: []Grant{
+				{Subject: rbacv1.Subject{Kind: "Group", Name: "auditors"}, Binding: "ClusterRoleBinding/viewers", Role: "ClusterRole/viewer"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := WhoCan(context.Background(), client, "team-a", tc.verb, controlPlanes)
+			if err != nil {
+				t.Fatalf("\n%s\nWhoCan(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nWhoCan(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package supportbundle collects diagnostic information about a control plane
+// into an archive that can be attached to support tickets.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upbound/up/internal/kube"
+)
+
+const (
+	externalNameAnnotation = "crossplane.io/external-name"
+	crossplaneSelector     = "app=crossplane"
+
+	rootDir = "support-bundle"
+	mode    = 0644
+
+	defaultLogLines   = 1000
+	defaultEventLimit = 500
+
+	errWriteBundle   = "unable to write support bundle"
+	errFmtCollect    = "unable to collect %s"
+	errFmtListKind   = "unable to list %s: %s"
+	errFmtGetLogs    = "unable to get logs of %s/%s container %s: %s"
+	errServerVersion = "unable to get Kubernetes version"
+)
+
+var (
+	crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+	packageGVRs = []schema.GroupVersionResource{
+		{Group: "pkg.crossplane.io", Version: "v1", Resource: "providers"},
+		{Group: "pkg.crossplane.io", Version: "v1", Resource: "configurations"},
+		{Group: "pkg.crossplane.io", Version: "v1beta1", Resource: "functions"},
+	}
+
+	// inventoryCategories are the categories of the kinds included in the
+	// resource inventory.
+	inventoryCategories = []string{"claim", "composite", "managed"}
+)
+
+// Versions are the versions of Kubernetes and Crossplane.
+type Versions struct {
+	Kubernetes string       `json:"kubernetes"`
+	Crossplane []Deployment `json:"crossplane"`
+}
+
+// Deployment is a Crossplane deployment.
+type Deployment struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Images    []string `json:"images"`
+}
+
+// Package is an installed Crossplane package.
+type Package struct {
+	Kind       string           `json:"kind"`
+	Name       string           `json:"name"`
+	Package    string           `json:"package"`
+	Revision   string           `json:"revision,omitempty"`
+	Conditions []xpv1.Condition `json:"conditions,omitempty"`
+}
+
+// InventoryEntry counts the objects of a kind. Objects are counted, not
+// listed, so that the inventory does not contain their names.
+type InventoryEntry struct {
+	Group    string `json:"group"`
+	Kind     string `json:"kind"`
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+	Ready    int    `json:"ready"`
+}
+
+// Event is a sanitized Kubernetes event.
+type Event struct {
+	LastSeen time.Time `json:"lastSeen"`
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Object   string    `json:"object"`
+	Count    int32     `json:"count,omitempty"`
+	Message  string    `json:"message"`
+}
+
+// Result summarizes a collected support bundle.
+type Result struct {
+	// Files are the files written to the bundle.
+	Files []string
+	// Warnings are the parts of the bundle that could not be collected.
+	Warnings []string
+}
+
+// Collector collects support bundles.
+type Collector struct {
+	kClient    kubernetes.Interface
+	dClient    dynamic.Interface
+	logLines   int64
+	eventLimit int
+}
+
+// Option modifies a Collector.
+type Option func(*Collector)
+
+// WithLogLines sets the number of log lines collected per container.
+func WithLogLines(n int64) Option {
+	return func(c *Collector) {
+		c.logLines = n
+	}
+}
+
+// WithEventLimit sets the number of most recent events collected.
+func WithEventLimit(n int) Option {
+	return func(c *Collector) {
+		c.eventLimit = n
+	}
+}
+
+// NewCollector constructs a Collector for the control plane the supplied
+// clients connect to.
+func NewCollector(kClient kubernetes.Interface, dClient dynamic.Interface, opts ...Option) *Collector {
+	c := &Collector{
+		kClient:    kClient,
+		dClient:    dClient,
+		logLines:   defaultLogLines,
+		eventLimit: defaultEventLimit,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+type bundleWriter struct {
+	tw  *tar.Writer
+	res *Result
+}
+
+func (w *bundleWriter) write(name string, b []byte) error {
+	name = path.Join(rootDir, name)
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    mode,
+		Size:    int64(len(b)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(b); err != nil {
+		return err
+	}
+	w.res.Files = append(w.res.Files, name)
+	return nil
+}
+
+func (w *bundleWriter) writeJSON(name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.write(name, b)
+}
+
+// Collect writes a gzipped tar archive with the versions, packages, recent
+// events, logs and resource inventory of the control plane to the supplied
+// writer. Logs and events are redacted of credentials and of the external
+// names of managed resources. Secrets are never collected.
+func (c *Collector) Collect(ctx context.Context, w io.Writer) (*Result, error) { //nolint:gocyclo
+	gw := gzip.NewWriter(w)
+	bw := &bundleWriter{tw: tar.NewWriter(gw), res: &Result{}}
+	redactor := NewRedactor()
+
+	inventory, err := c.inventory(ctx, redactor, bw.res)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtCollect, "resource inventory")
+	}
+	if err := bw.writeJSON("inventory.json", inventory); err != nil {
+		return nil, errors.Wrap(err, errWriteBundle)
+	}
+
+	versions, err := c.versions(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtCollect, "versions")
+	}
+	if err := bw.writeJSON("versions.json", versions); err != nil {
+		return nil, errors.Wrap(err, errWriteBundle)
+	}
+
+	pkgs, err := c.packages(ctx, redactor)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtCollect, "packages")
+	}
+	if err := bw.writeJSON("packages.json", pkgs); err != nil {
+		return nil, errors.Wrap(err, errWriteBundle)
+	}
+
+	events, err := c.events(ctx, redactor)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtCollect, "events")
+	}
+	if err := bw.writeJSON("events.json", events); err != nil {
+		return nil, errors.Wrap(err, errWriteBundle)
+	}
+
+	namespaces := map[string]bool{}
+	for _, d := range versions.Crossplane {
+		namespaces[d.Namespace] = true
+	}
+	for ns := range namespaces {
+		if err := c.logs(ctx, ns, redactor, bw); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := bw.tw.Close(); err != nil {
+		return nil, errors.Wrap(err, errWriteBundle)
+	}
+	return bw.res, errors.Wrap(gw.Close(), errWriteBundle)
+}
+
+// inventory counts the claims, composite and managed resources of each kind,
+// and adds the external names of managed resources to the redactor.
+func (c *Collector) inventory(ctx context.Context, redactor *Redactor, res *Result) ([]InventoryEntry, error) {
+	crds, err := c.dClient.Resource(crdGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	entries := []InventoryEntry{}
+	for _, crd := range crds.Items {
+		category, ok := inventoryCategory(&crd)
+		if !ok {
+			continue
+		}
+		p := fieldpath.Pave(crd.Object)
+		group, _ := p.GetString("spec.group")
+		kind, _ := p.GetString("spec.names.kind")
+		plural, _ := p.GetString("spec.names.plural")
+		version := storageVersion(&crd)
+		if version == "" {
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: plural}
+		l, err := c.dClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf(errFmtListKind, gvr.GroupResource(), err))
+			continue
+		}
+		e := InventoryEntry{Group: group, Kind: kind, Category: category, Count: len(l.Items)}
+		for i := range l.Items {
+			u := &l.Items[i]
+			if kube.IsReady(u) {
+				e.Ready++
+			}
+			redactor.Add(u.GetAnnotations()[externalNameAnnotation])
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Group != entries[j].Group {
+			return entries[i].Group < entries[j].Group
+		}
+		return entries[i].Kind < entries[j].Kind
+	})
+	return entries, nil
+}
+
+func inventoryCategory(crd *unstructured.Unstructured) (string, bool) {
+	categories, _, _ := unstructured.NestedStringSlice(crd.Object, "spec", "names", "categories")
+	for _, ic := range inventoryCategories {
+		for _, c := range categories {
+			if c == ic {
+				return ic, true
+			}
+		}
+	}
+	return "", false
+}
+
+func storageVersion(crd *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if storage, _ := m["storage"].(bool); storage {
+			name, _ := m["name"].(string)
+			return name
+		}
+	}
+	return ""
+}
+
+func (c *Collector) versions(ctx context.Context) (*Versions, error) {
+	v := &Versions{Crossplane: []Deployment{}}
+	sv, err := c.kClient.Discovery().ServerVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, errServerVersion)
+	}
+	v.Kubernetes = sv.GitVersion
+
+	deps, err := c.kClient.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: crossplaneSelector})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deps.Items {
+		dep := Deployment{Namespace: d.Namespace, Name: d.Name, Images: []string{}}
+		for _, ctr := range d.Spec.Template.Spec.Containers {
+			dep.Images = append(dep.Images, ctr.Image)
+		}
+		v.Crossplane = append(v.Crossplane, dep)
+	}
+	return v, nil
+}
+
+func (c *Collector) packages(ctx context.Context, redactor *Redactor) ([]Package, error) {
+	pkgs := []Package{}
+	for _, gvr := range packageGVRs {
+		l, err := c.dClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if kerrors.IsNotFound(err) {
+			// The package kind is not served by this version of Crossplane.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range l.Items {
+			u := &l.Items[i]
+			p := fieldpath.Pave(u.Object)
+			pkg := Package{Kind: u.GetKind(), Name: u.GetName()}
+			pkg.Package, _ = p.GetString("spec.package")
+			pkg.Revision, _ = p.GetString("status.currentRevision")
+			cs := xpv1.ConditionedStatus{}
+			_ = p.GetValueInto("status", &cs)
+			for _, cond := range cs.Conditions {
+				cond.Message = redactor.Redact(cond.Message)
+				pkg.Conditions = append(pkg.Conditions, cond)
+			}
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+func (c *Collector) events(ctx context.Context, redactor *Redactor) ([]Event, error) {
+	l, err := c.kClient.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(l.Items))
+	for _, e := range l.Items {
+		events = append(events, Event{
+			LastSeen: eventTime(e),
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Object:   redactor.Redact(path.Join(e.InvolvedObject.Kind, e.InvolvedObject.Namespace, e.InvolvedObject.Name)),
+			Count:    e.Count,
+			Message:  redactor.Redact(e.Message),
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+	if len(events) > c.eventLimit {
+		events = events[:c.eventLimit]
+	}
+	return events, nil
+}
+
+// logs collects the redacted logs of the containers of the pods in the
+// supplied namespace, which include Crossplane and its providers.
+func (c *Collector) logs(ctx context.Context, namespace string, redactor *Redactor, bw *bundleWriter) error {
+	pods, err := c.kClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, errFmtCollect, "pods")
+	}
+	for _, pod := range pods.Items {
+		for _, ctr := range pod.Spec.Containers {
+			b, err := c.kClient.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: ctr.Name,
+				TailLines: &c.logLines,
+			}).DoRaw(ctx)
+			if err != nil {
+				bw.res.Warnings = append(bw.res.Warnings, fmt.Sprintf(errFmtGetLogs, namespace, pod.Name, ctr.Name, err))
+				continue
+			}
+			name := path.Join("logs", namespace, pod.Name, ctr.Name+".log")
+			if err := bw.write(name, []byte(redactor.Redact(string(b)))); err != nil {
+				return errors.Wrap(err, errWriteBundle)
+			}
+		}
+	}
+	return nil
+}
+
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollect(t *testing.T) {
+	bucketGVR := schema.GroupVersionResource{Group: "s3.aws.upbound.io", Version: "v1beta1", Resource: "buckets"}
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "buckets.s3.aws.upbound.io"},
+		"spec": map[string]any{
+			"group":    "s3.aws.upbound.io",
+			"names":    map[string]any{"kind": "Bucket", "plural": "buckets", "categories": []any{"crossplane", "managed", "aws"}},
+			"versions": []any{map[string]any{"name": "v1beta1", "storage": true}},
+		},
+	}}
+	bucket := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "s3.aws.upbound.io/v1beta1",
+		"kind":       "Bucket",
+		"metadata": map[string]any{
+			"name":        "bucket",
+			"annotations": map[string]any{externalNameAnnotation: "cool-secret-bucket"},
+		},
+	}}
+	dClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		crdGVR:         "CustomResourceDefinitionList",
+		bucketGVR:      "BucketList",
+		packageGVRs[0]: "ProviderList",
+		packageGVRs[1]: "ConfigurationList",
+		packageGVRs[2]: "FunctionList",
+	}, crd, bucket)
+
+	kClient := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "crossplane", Labels: map[string]string{"app": "crossplane"}},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "crossplane", Image: "crossplane/crossplane:v1.14.1"}},
+			}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "crossplane-1"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "crossplane"}}},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "e1"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Bucket", Name: "bucket"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "CannotObserveExternalResource",
+			Message:        "cannot get bucket cool-secret-bucket with token=abc",
+		},
+	)
+
+	buf := &bytes.Buffer{}
+	res, err := NewCollector(kClient, dClient).Collect(context.Background(), buf)
+	if err != nil {
+		t.Fatalf("Collect(...): %s", err)
+	}
+	wantFiles := []string{
+		"support-bundle/inventory.json",
+		"support-bundle/versions.json",
+		"support-bundle/packages.json",
+		"support-bundle/events.json",
+		"support-bundle/logs/crossplane-system/crossplane-1/crossplane.log",
+	}
+	if diff := cmp.Diff(wantFiles, res.Files); diff != "" {
+		t.Errorf("Collect(...): -want files, +got files:\n%s", diff)
+	}
+
+	files := readBundle(t, buf)
+	inventory := []InventoryEntry{}
+	if err := json.Unmarshal(files["support-bundle/inventory.json"], &inventory); err != nil {
+		t.Fatal(err)
+	}
+	wantInventory := []InventoryEntry{{Group: "s3.aws.upbound.io", Kind: "Bucket", Category: "managed", Count: 1, Ready: 1}}
+	if diff := cmp.Diff(wantInventory, inventory); diff != "" {
+		t.Errorf("Collect(...): -want inventory, +got inventory:\n%s", diff)
+	}
+
+	events := []Event{}
+	if err := json.Unmarshal(files["support-bundle/events.json"], &events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Collect(...): want 1 event, got %d", len(events))
+	}
+	if diff := cmp.Diff("cannot get bucket [REDACTED] with token=[REDACTED]", events[0].Message); diff != "" {
+		t.Errorf("Collect(...): -want event message, +got event message:\n%s", diff)
+	}
+}
+
+func readBundle(t *testing.T, r io.Reader) map[string][]byte {
+	t.Helper()
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{}
+	tr := tar.NewReader(gr)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[h.Name] = b
+	}
+}
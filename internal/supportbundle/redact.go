@@ -0,0 +1,96 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundle
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces sensitive data in a support bundle.
+const Redacted = "[REDACTED]"
+
+// minRedactedLength is the minimum length of a known sensitive value to be
+// redacted. Shorter values would redact unrelated parts of logs.
+const minRedactedLength = 4
+
+type pattern struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// patterns match sensitive data that commonly appears in logs and events.
+var patterns = []pattern{
+	{re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), repl: Redacted},
+	{re: regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`), repl: "${1}" + Redacted},
+	{re: regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key)["']?\s*[:=]\s*["']?)[^\s"',}]+`), repl: "${1}" + Redacted},
+	{re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), repl: Redacted},
+}
+
+// A Redactor removes sensitive data, such as credentials and the external
+// names of managed resources, from text.
+type Redactor struct {
+	values map[string]bool
+	r      *strings.Replacer
+}
+
+// NewRedactor returns a Redactor that redacts the supplied values in addition
+// to common credential patterns.
+func NewRedactor(values ...string) *Redactor {
+	r := &Redactor{values: map[string]bool{}}
+	r.Add(values...)
+	return r
+}
+
+// Add adds values to be redacted.
+func (r *Redactor) Add(values ...string) {
+	for _, v := range values {
+		if len(v) >= minRedactedLength {
+			r.values[v] = true
+		}
+	}
+	r.r = nil
+}
+
+// Redact returns the supplied text with sensitive data replaced.
+func (r *Redactor) Redact(s string) string {
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	if len(r.values) == 0 {
+		return s
+	}
+	if r.r == nil {
+		vals := make([]string, 0, len(r.values))
+		for v := range r.values {
+			vals = append(vals, v)
+		}
+		// Prefer longer values, so that a value containing another one is
+		// redacted as a whole.
+		sort.Slice(vals, func(i, j int) bool {
+			if len(vals[i]) != len(vals[j]) {
+				return len(vals[i]) > len(vals[j])
+			}
+			return vals[i] < vals[j]
+		})
+		pairs := make([]string, 0, 2*len(vals))
+		for _, v := range vals {
+			pairs = append(pairs, v, Redacted)
+		}
+		r.r = strings.NewReplacer(pairs...)
+	}
+	return r.r.Replace(s)
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports OpenTelemetry spans of CLI operations.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/upbound/up/internal/version"
+)
+
+// EndpointEnv is the environment variable that holds the OTLP gRPC endpoint
+// spans are exported to. Tracing is disabled if it is not set.
+const EndpointEnv = "UP_OTEL_ENDPOINT"
+
+const (
+	tracerName = "github.com/upbound/up"
+
+	errParseEndpoint  = "unable to parse " + EndpointEnv
+	errCreateExporter = "unable to create OTLP trace exporter"
+)
+
+var (
+	// root is the context of the span of the running command. Spans started
+	// with Start are descendants of it.
+	root = context.Background()
+
+	// active are the contexts of the spans started with Start that have not
+	// ended yet, innermost last.
+	mu     sync.Mutex
+	active []context.Context
+
+	// propagator propagates the trace context to the servers requests are
+	// sent to.
+	propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+)
+
+// Setup configures the global tracer provider to export spans to the endpoint
+// in UP_OTEL_ENDPOINT, e.g. localhost:4317 or http://localhost:4317 for a
+// collector without TLS. The returned function flushes pending spans and must
+// be called before exiting. Setup does nothing if UP_OTEL_ENDPOINT is not set.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	endpoint := os.Getenv(EndpointEnv)
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	opts := []otlptracegrpc.Option{}
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, errParseEndpoint)
+		}
+		endpoint = u.Host
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+	}
+	exp, err := otlptracegrpc.New(ctx, append(opts, otlptracegrpc.WithEndpoint(endpoint))...)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateExporter)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("up"),
+			semconv.ServiceVersion(version.GetVersion()),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagator)
+	return tp.Shutdown, nil
+}
+
+// StartCommand starts the root span of the supplied command. Spans started
+// with Start until the returned span ends are its children.
+func StartCommand(name string, attrs ...attribute.KeyValue) trace.Span {
+	var span trace.Span
+	root, span = otel.Tracer(tracerName).Start(context.Background(), name, trace.WithAttributes(attrs...))
+	return span
+}
+
+// Start starts a span as a child of the innermost span started with Start
+// that has not ended yet, or of the span of the running command. The returned
+// context carries the span and should be passed to the operation the span
+// records.
+func Start(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(current(), name, trace.WithAttributes(attrs...))
+	mu.Lock()
+	defer mu.Unlock()
+	active = append(active, ctx)
+	return ctx, span
+}
+
+// End records the supplied error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range active {
+		if trace.SpanFromContext(active[i]) == span {
+			active = append(active[:i], active[i+1:]...)
+			break
+		}
+	}
+}
+
+// current returns the context of the innermost span started with Start that
+// has not ended yet, or that of the span of the running command.
+func current() context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(active) == 0 {
+		return root
+	}
+	return active[len(active)-1]
+}
+
+// Transport wraps the supplied transport to record a span for each request
+// and to propagate the trace context to the server. Requests whose context
+// does not carry a span, e.g. because the operation was not passed the
+// context returned by Start, are recorded as children of the innermost
+// running span.
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{next: otelhttp.NewTransport(rt, otelhttp.WithPropagators(propagator))}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		req = req.WithContext(trace.ContextWithSpan(req.Context(), trace.SpanFromContext(current())))
+	}
+	return t.next.RoundTrip(req)
+}
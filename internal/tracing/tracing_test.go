@@ -0,0 +1,95 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpans(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+
+	cmd := StartCommand("up space init")
+	_, step := Start("Installing Spaces")
+	End(step, errors.New("boom"))
+	End(cmd, nil)
+
+	spans := rec.Ended()
+	if diff := cmp.Diff(2, len(spans)); diff != "" {
+		t.Fatalf("Ended(): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID()); diff != "" {
+		t.Errorf("Start(...): step should be a child of the command span: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(codes.Error, spans[0].Status().Code); diff != "" {
+		t.Errorf("End(...): failed step should have error status: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(codes.Unset, spans[1].Status().Code); diff != "" {
+		t.Errorf("End(...): succeeded command should have unset status: -want, +got:\n%s", diff)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("traceparent")
+	}))
+	defer srv.Close()
+
+	cmd := StartCommand("up controlplane apply")
+	_, step := Start("Applying manifests")
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest(...): %v", err)
+	}
+	res, err := (&http.Client{Transport: Transport(http.DefaultTransport)}).Do(req)
+	if err != nil {
+		t.Fatalf("Do(...): %v", err)
+	}
+	_ = res.Body.Close()
+	End(step, nil)
+	End(cmd, nil)
+
+	spans := rec.Ended()
+	if diff := cmp.Diff(3, len(spans)); diff != "" {
+		t.Fatalf("Ended(): -want, +got:\n%s", diff)
+	}
+	req0, step0 := spans[0], spans[1]
+	if diff := cmp.Diff(step0.SpanContext().SpanID(), req0.Parent().SpanID()); diff != "" {
+		t.Errorf("Transport(...): request without a span in its context should be a child of the running step: -want, +got:\n%s", diff)
+	}
+	want := "00-" + req0.SpanContext().TraceID().String() + "-" + req0.SpanContext().SpanID().String() + "-01"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Transport(...): trace context should be propagated to the server: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(trace.SpanKindClient, req0.SpanKind()); diff != "" {
+		t.Errorf("Transport(...): requests should be recorded as client spans: -want, +got:\n%s", diff)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/upbound/up-sdk-go"
 
 	"github.com/upbound/up/internal/config"
+	"github.com/upbound/up/internal/tracing"
 )
 
 const (
@@ -231,6 +232,7 @@ func (c *Context) BuildSDKConfig() (*up.Config, error) {
 			InsecureSkipVerify: c.InsecureSkipTLSVerify, //nolint:gosec
 		},
 	}
+	tr = tracing.Transport(tr)
 	if c.WrapTransport != nil {
 		tr = c.WrapTransport(tr)
 	}
@@ -0,0 +1,110 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upterm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+)
+
+// ProgressMode determines how the progress of long running steps is printed.
+type ProgressMode string
+
+const (
+	// ProgressAuto prints spinners when progress is written to a terminal,
+	// and plain text otherwise.
+	ProgressAuto ProgressMode = "auto"
+	// ProgressSpinner prints animated spinners.
+	ProgressSpinner ProgressMode = "spinner"
+	// ProgressPlain prints a line when a step starts and when it finishes.
+	ProgressPlain ProgressMode = "plain"
+	// ProgressJSON prints a JSON object per line when a step starts and when
+	// it finishes.
+	ProgressJSON ProgressMode = "json"
+)
+
+const (
+	statusStarted   = "started"
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+)
+
+var (
+	progressMode             = ProgressSpinner
+	progressWriter io.Writer = os.Stderr
+)
+
+// SetProgress configures how progress is printed by WrapWithSuccessSpinner,
+// and where it is written to. Progress should be written to stderr, so that it
+// does not interleave with the output of commands.
+func SetProgress(m ProgressMode, w io.Writer) {
+	if m == ProgressAuto {
+		m = ProgressPlain
+		if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			m = ProgressSpinner
+		}
+	}
+	progressMode, progressWriter = m, w
+}
+
+// progressEvent is a step progress update printed in JSON mode.
+type progressEvent struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+	Status  string    `json:"status"`
+	Error   string    `json:"error,omitempty"`
+}
+
+func printProgress(msg, status string, err error) {
+	switch progressMode { //nolint:exhaustive
+	case ProgressJSON:
+		e := progressEvent{Time: time.Now().UTC(), Message: msg, Status: status}
+		if err != nil {
+			e.Error = err.Error()
+		}
+		b, _ := json.Marshal(e)
+		fmt.Fprintln(progressWriter, string(b))
+	default:
+		switch status {
+		case statusStarted:
+			fmt.Fprintf(progressWriter, "%s...\n", msg)
+		case statusSucceeded:
+			fmt.Fprintf(progressWriter, "%s: done\n", msg)
+		case statusFailed:
+			fmt.Fprintf(progressWriter, "%s: failed\n", msg)
+		}
+	}
+}
+
+func wrapWithSpinner(msg string, spinner *pterm.SpinnerPrinter, f func() error) error {
+	s, err := spinner.WithWriter(progressWriter).Start(msg)
+	if err != nil {
+		return err
+	}
+
+	if err := f(); err != nil {
+		_ = s.Stop()
+		return err
+	}
+
+	s.Success()
+	return nil
+}
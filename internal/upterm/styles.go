@@ -16,8 +16,11 @@ package upterm
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/pterm/pterm"
+
+	"github.com/upbound/up/internal/tracing"
 )
 
 var (
@@ -58,20 +61,60 @@ func init() {
 	EyesInfoSpinner.InfoPrinter = ip
 }
 
-func WrapWithSuccessSpinner(msg string, spinner *pterm.SpinnerPrinter, f func() error) error {
-	s, err := spinner.Start(msg)
-	if err != nil {
-		return err
+// WrapWithSuccessSpinner runs the supplied function and reports its progress
+// using the configured ProgressMode. The step is recorded as a trace span.
+func WrapWithSuccessSpinner(msg string, spinner *pterm.SpinnerPrinter, f func() error) (err error) {
+	_, span := tracing.Start(msg)
+	defer func() { tracing.End(span, err) }()
+
+	if progressMode == ProgressSpinner {
+		return wrapWithSpinner(msg, spinner, f)
 	}
 
+	printProgress(msg, statusStarted, nil)
 	if err := f(); err != nil {
+		printProgress(msg, statusFailed, err)
 		return err
 	}
-
-	s.Success()
+	printProgress(msg, statusSucceeded, nil)
 	return nil
 }
 
+// WrapWithProgressBar runs the supplied function and reports its progress
+// according to the configured progress mode. The function is passed a function
+// to report the completed percentage of the step, which must not be called
+// once the function has returned. In spinner mode a progress bar is printed,
+// otherwise progress is printed as for WrapWithSuccessSpinner.
+func WrapWithProgressBar(msg string, f func(progress func(pct int)) error) (err error) {
+	_, span := tracing.Start(msg)
+	defer func() { tracing.End(span, err) }()
+
+	if progressMode != ProgressSpinner {
+		printProgress(msg, statusStarted, nil)
+		if err := f(func(int) {}); err != nil {
+			printProgress(msg, statusFailed, err)
+			return err
+		}
+		printProgress(msg, statusSucceeded, nil)
+		return nil
+	}
+
+	bar, err := pterm.DefaultProgressbar.WithWriter(progressWriter).WithTotal(100).WithTitle(msg).WithRemoveWhenDone(true).Start()
+	if err != nil {
+		return err
+	}
+	var mu sync.Mutex
+	err = f(func(pct int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if pct > bar.Current {
+			bar.Add(pct - bar.Current)
+		}
+	})
+	_, _ = bar.Stop()
+	return err
+}
+
 func StepCounter(msg string, index, total int) string {
 	return fmt.Sprintf("[%d/%d]: %s", index, total, msg)
 }
@@ -0,0 +1,127 @@
+// Copyright 2021 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upterm
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/pterm/pterm"
+)
+
+// Theme is a set of styles and symbols used to print output.
+type Theme string
+
+// Supported themes.
+const (
+	// ThemeDark is the default theme, designed for dark terminal backgrounds.
+	ThemeDark Theme = "dark"
+	// ThemeLight avoids colors that are hard to read on light backgrounds.
+	ThemeLight Theme = "light"
+	// ThemeColorblind distinguishes states by blue, yellow and magenta
+	// instead of green and red, and by symbols in addition to colors.
+	ThemeColorblind Theme = "colorblind"
+	// ThemePlain prints no colors and only ASCII symbols.
+	ThemePlain Theme = "plain"
+)
+
+const errFmtUnknownTheme = "unknown theme %q, must be one of plain, dark, light or colorblind"
+
+var (
+	// SuccessStyle is the style of values that indicate success, such as a
+	// healthy control plane.
+	SuccessStyle = &pterm.Style{pterm.FgGreen}
+	// WarningStyle is the style of values that indicate a transitional or
+	// degraded state.
+	WarningStyle = &pterm.Style{pterm.FgYellow}
+	// ErrorStyle is the style of values that indicate failure.
+	ErrorStyle = &pterm.Style{pterm.FgLightRed}
+)
+
+type themeStyles struct {
+	accent  pterm.Color
+	spinner pterm.Color
+	success pterm.Color
+	warning pterm.Color
+	err     pterm.Color
+
+	successBg pterm.Color
+	warningBg pterm.Color
+	errBg     pterm.Color
+
+	checkmark string
+	eyes      string
+	raised    string
+	warn      string
+	fail      string
+}
+
+var themes = map[Theme]themeStyles{
+	ThemeDark: {
+		accent: pterm.FgLightMagenta, spinner: pterm.FgDarkGray,
+		success: pterm.FgGreen, warning: pterm.FgYellow, err: pterm.FgLightRed,
+		successBg: pterm.BgGreen, warningBg: pterm.BgYellow, errBg: pterm.BgLightRed,
+		checkmark: " √ ", eyes: " 👀", raised: " 🙌", warn: "WARNING", fail: "ERROR",
+	},
+	ThemeLight: {
+		accent: pterm.FgBlue, spinner: pterm.FgGray,
+		success: pterm.FgGreen, warning: pterm.FgMagenta, err: pterm.FgRed,
+		successBg: pterm.BgGreen, warningBg: pterm.BgMagenta, errBg: pterm.BgRed,
+		checkmark: " √ ", eyes: " 👀", raised: " 🙌", warn: "WARNING", fail: "ERROR",
+	},
+	ThemeColorblind: {
+		accent: pterm.FgBlue, spinner: pterm.FgGray,
+		success: pterm.FgBlue, warning: pterm.FgYellow, err: pterm.FgMagenta,
+		successBg: pterm.BgBlue, warningBg: pterm.BgYellow, errBg: pterm.BgMagenta,
+		checkmark: " ✔ ", eyes: " ▸ ", raised: " ★ ", warn: "! WARNING", fail: "✖ ERROR",
+	},
+	ThemePlain: {
+		accent: pterm.FgDefault, spinner: pterm.FgDefault,
+		success: pterm.FgDefault, warning: pterm.FgDefault, err: pterm.FgDefault,
+		successBg: pterm.BgDefault, warningBg: pterm.BgDefault, errBg: pterm.BgDefault,
+		checkmark: " ok ", eyes: " * ", raised: " * ", warn: "WARNING", fail: "ERROR",
+	},
+}
+
+// SetTheme applies the supplied theme to the printers of this package and to
+// the default pterm printers. An empty theme selects ThemeDark.
+func SetTheme(t Theme) error {
+	if t == "" {
+		t = ThemeDark
+	}
+	s, ok := themes[t]
+	if !ok {
+		return errors.Errorf(errFmtUnknownTheme, t)
+	}
+	if t == ThemePlain {
+		pterm.DisableColor()
+	}
+
+	*SuccessStyle = pterm.Style{s.success}
+	*WarningStyle = pterm.Style{s.warning}
+	*ErrorStyle = pterm.Style{s.err}
+	*spinnerStyle = pterm.Style{s.spinner}
+
+	EyesPrefix = pterm.Prefix{Style: &pterm.Style{s.accent}, Text: s.eyes}
+	RaisedPrefix = pterm.Prefix{Style: &pterm.Style{s.accent}, Text: s.raised}
+	cp.Prefix = pterm.Prefix{Style: &pterm.Style{s.accent}, Text: s.checkmark}
+	ip.Prefix = EyesPrefix
+
+	pterm.Success.Prefix.Style = &pterm.Style{pterm.FgBlack, s.successBg}
+	pterm.Success.MessageStyle = SuccessStyle
+	pterm.Warning.Prefix = pterm.Prefix{Style: &pterm.Style{pterm.FgBlack, s.warningBg}, Text: s.warn}
+	pterm.Warning.MessageStyle = WarningStyle
+	pterm.Error.Prefix = pterm.Prefix{Style: &pterm.Style{pterm.FgBlack, s.errBg}, Text: s.fail}
+	pterm.Error.MessageStyle = ErrorStyle
+	return nil
+}
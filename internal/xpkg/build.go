@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	pkgmetav1 "github.com/crossplane/crossplane/apis/pkg/meta/v1"
@@ -119,7 +120,8 @@ func New(pkg, ab, ex parser.Backend, pp parser.Parser, ep *examples.Parser) *Bui
 }
 
 type buildOpts struct {
-	base v1.Image
+	base    v1.Image
+	created *time.Time
 }
 
 // A BuildOpt modifies how a package is built.
@@ -133,6 +135,15 @@ func WithController(img v1.Image) BuildOpt {
 	}
 }
 
+// WithCreated sets the modification time of the package files and the creation
+// time of the image and its history to the supplied time, so that identical
+// inputs produce an image with an identical digest.
+func WithCreated(t time.Time) BuildOpt {
+	return func(o *buildOpts) {
+		o.created = &t
+	}
+}
+
 type AuthExtension struct {
 	Version      string `yaml:"version"`
 	Discriminant string `yaml:"discriminant"`
@@ -252,7 +263,12 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 		return nil, nil, errors.Wrap(err, errConfigFile)
 	}
 
-	pkgLayer, err := Layer(pkgBytes, StreamFile, PackageAnnotation, int64(pkgBytes.Len()), StreamFileMode, &cfg)
+	var modTime time.Time
+	if bOpts.created != nil {
+		modTime = *bOpts.created
+	}
+
+	pkgLayer, err := layer(pkgBytes, StreamFile, PackageAnnotation, int64(pkgBytes.Len()), StreamFileMode, modTime, &cfg)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -265,7 +281,7 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 			return nil, nil, errors.Wrap(err, errParserExample)
 		}
 
-		exLayer, err := Layer(exBuf, XpkgExamplesFile, ExamplesAnnotation, int64(exBuf.Len()), StreamFileMode, &cfg)
+		exLayer, err := layer(exBuf, XpkgExamplesFile, ExamplesAnnotation, int64(exBuf.Len()), StreamFileMode, modTime, &cfg)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -284,9 +300,33 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 		return nil, nil, errors.Wrap(err, errMutateConfig)
 	}
 
+	if bOpts.created != nil {
+		bOpts.base, err = setCreated(bOpts.base, *bOpts.created)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errMutateConfig)
+		}
+	}
+
 	return bOpts.base, meta, nil
 }
 
+// setCreated sets the creation time of the image and each of its history
+// entries to the supplied time. Unlike mutate.Time it does not rewrite the
+// layers, which would invalidate the layer annotations in the config.
+func setCreated(img v1.Image, t time.Time) (v1.Image, error) {
+	cfgFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	cfgFile = cfgFile.DeepCopy()
+	cfgFile.Created = v1.Time{Time: t}
+	for i := range cfgFile.History {
+		cfgFile.History[i].Created = v1.Time{Time: t}
+		cfgFile.History[i].Author = ""
+	}
+	return mutate.ConfigFile(img, cfgFile)
+}
+
 // encode encodes a package as a YAML stream.  Does not check meta existence
 // or quantity i.e. it should be linted first to ensure that it is valid.
 func encode(pkg linter.Package) (*bytes.Buffer, error) {
@@ -21,6 +21,7 @@ import (
 	"os"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/parser"
@@ -282,6 +283,56 @@ func TestBuildExamples(t *testing.T) {
 	}
 }
 
+func TestBuildWithCreated(t *testing.T) {
+	pkgp, _ := yaml.New()
+	created := time.Unix(1700000000, 0).UTC()
+
+	build := func() v1.Image {
+		fs := afero.NewMemMapFs()
+		_ = afero.WriteFile(fs, "/ws/crossplane.yaml", testMeta, os.ModePerm)
+		_ = afero.WriteFile(fs, "/ws/crds/crd.yaml", testCRD, os.ModePerm)
+		_ = afero.WriteFile(fs, "/ws/examples/provider.yaml", testEx4, os.ModePerm)
+		pkgBe := parser.NewFsBackend(fs, parser.FsDir("/ws"), parser.FsFilters(
+			parser.SkipDirs(),
+			parser.SkipNotYAML(),
+			parser.SkipEmpty(),
+			SkipContains("examples/"),
+		))
+		pkgEx := parser.NewFsBackend(fs, parser.FsDir("/ws/examples"), parser.FsFilters(
+			parser.SkipDirs(),
+			parser.SkipNotYAML(),
+			parser.SkipEmpty(),
+		))
+		img, _, err := New(pkgBe, nil, pkgEx, pkgp, examples.New()).Build(context.TODO(), WithCreated(created))
+		if err != nil {
+			t.Fatalf("Build(...): %v", err)
+		}
+		return img
+	}
+
+	a, b := build(), build()
+	da, _ := a.Digest()
+	db, _ := b.Digest()
+	if diff := cmp.Diff(da, db); diff != "" {
+		t.Errorf("Build(...): builds of identical inputs should have the same digest: -first, +second:\n%s", diff)
+	}
+	cfg, err := a.ConfigFile()
+	if err != nil {
+		t.Fatalf("ConfigFile(): %v", err)
+	}
+	if diff := cmp.Diff(created, cfg.Created.Time); diff != "" {
+		t.Errorf("Build(...): -want created, +got created:\n%s", diff)
+	}
+	// The layer annotations must still reference the layers of the image.
+	contents, err := readImg(a)
+	if err != nil {
+		t.Fatalf("readImg(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{ExamplesAnnotation, PackageAnnotation}, contents.labels, cmpopts.SortSlices(func(i, j string) bool { return i < j })); diff != "" {
+		t.Errorf("Build(...): -want labels, +got labels:\n%s", diff)
+	}
+}
+
 type xpkgContents struct {
 	labels   []string
 	pkgBytes []byte
@@ -17,6 +17,7 @@ package dep
 import (
 	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/up/internal/xpkg/dep/resolver/image"
@@ -58,3 +59,20 @@ func NewWithType(pkg string, t string) v1beta1.Dependency {
 
 	return d
 }
+
+// CompatibleConstraint returns the constraint matching the versions of the
+// given v1beta1.Dependency that are compatible with its current constraint.
+// If the constraint pins an exact version, the returned constraint also
+// matches newer versions with the same major version, or with the same minor
+// version for major version 0, as minor versions may break compatibility
+// before 1.0.0. Otherwise the constraint is returned unchanged.
+func CompatibleConstraint(d v1beta1.Dependency) string {
+	v, err := semver.NewVersion(d.Constraints)
+	if err != nil {
+		return d.Constraints
+	}
+	if v.Major() == 0 {
+		return "~" + v.Original()
+	}
+	return "^" + v.Original()
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Masterminds/semver"
 	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
 	"github.com/google/go-cmp/cmp"
 
@@ -91,3 +92,82 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestCompatibleConstraint(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		dep    v1beta1.Dependency
+		want   string
+	}{
+		"ExactVersion": {
+			reason: "An exact version should be widened to versions with the same major version.",
+			dep:    v1beta1.Dependency{Package: "crossplane/provider-aws", Constraints: "v1.2.0"},
+			want:   "^v1.2.0",
+		},
+		"ExactPreReleaseVersion": {
+			reason: "An exact 0.x version should only be widened to versions with the same minor version.",
+			dep:    v1beta1.Dependency{Package: "crossplane/provider-aws", Constraints: "v0.42.0"},
+			want:   "~v0.42.0",
+		},
+		"Range": {
+			reason: "A range should be returned unchanged.",
+			dep:    v1beta1.Dependency{Package: "crossplane/provider-aws", Constraints: ">=v1.0.0"},
+			want:   ">=v1.0.0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CompatibleConstraint(tc.dep)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCompatibleConstraint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompatibleConstraintMatches(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		version string
+		check   string
+		want    bool
+	}{
+		"SameMajor": {
+			reason:  "A newer minor version should be compatible with a 1.x version.",
+			version: "v1.2.0",
+			check:   "v1.3.0",
+			want:    true,
+		},
+		"NextMajor": {
+			reason:  "A newer major version should not be compatible.",
+			version: "v1.2.0",
+			check:   "v2.0.0",
+			want:    false,
+		},
+		"SameMinor": {
+			reason:  "A newer patch version should be compatible with a 0.x version.",
+			version: "v0.42.0",
+			check:   "v0.42.3",
+			want:    true,
+		},
+		"NextMinor": {
+			reason:  "A newer minor version should not be compatible with a 0.x version.",
+			version: "v0.42.0",
+			check:   "v0.43.0",
+			want:    false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := semver.NewConstraint(CompatibleConstraint(v1beta1.Dependency{Constraints: tc.version}))
+			if err != nil {
+				t.Fatalf("semver.NewConstraint(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, c.Check(semver.MustParse(tc.check))); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
+	"github.com/upbound/up/internal/feature"
 	ixpkg "github.com/upbound/up/internal/xpkg"
 	"github.com/upbound/up/internal/xpkg/dep/cache"
 	xpkg "github.com/upbound/up/internal/xpkg/dep/marshaler/xpkg"
@@ -55,6 +56,7 @@ type Manager struct {
 	log           logging.Logger
 	cacheRoot     string
 	watchInterval *time.Duration
+	offline       bool
 
 	acc []*xpkg.ParsedPackage
 }
@@ -154,6 +156,14 @@ func WithResolver(r ImageResolver) Option {
 	}
 }
 
+// WithOffline configures the Manager to resolve dependencies from the cache
+// only, without contacting registries.
+func WithOffline(offline bool) Option {
+	return func(m *Manager) {
+		m.offline = offline
+	}
+}
+
 // WithWatchInterval overrides the default watch interval for the Manager.
 func WithWatchInterval(i *time.Duration) Option {
 	return func(m *Manager) {
@@ -343,8 +353,16 @@ func (m *Manager) retrievePkg(ctx context.Context, d v1beta1.Dependency) (*xpkg.
 }
 
 func (m *Manager) retrieveAndStorePkg(ctx context.Context, d v1beta1.Dependency) (*xpkg.ParsedPackage, error) {
+	if m.offline {
+		return m.retrievePkg(ctx, d)
+	}
+
 	// resolve version prior to Get
 	if err := m.finalizeExtDepVersion(ctx, &d); err != nil {
+		if feature.IsNetworkError(err) {
+			m.log.Debug("Registry is unreachable, falling back to cache", "package", d.Package, "error", err)
+			return m.retrievePkg(ctx, d)
+		}
 		return nil, fmt.Errorf("failed to resolve %s:%s: %w", d.Package, d.Constraints, err)
 	}
 
@@ -362,6 +380,10 @@ func (m *Manager) retrieveAndStorePkg(ctx context.Context, d v1beta1.Dependency)
 	} else {
 		// check if digest is different from what we have locally
 		digest, err := m.i.ResolveDigest(ctx, d)
+		if feature.IsNetworkError(err) {
+			// keep what we have if the registry is unreachable
+			return p, nil
+		}
 		if err != nil {
 			return nil, err
 		}
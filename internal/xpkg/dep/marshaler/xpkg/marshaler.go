@@ -116,6 +116,17 @@ func (r *Marshaler) FromImage(i xpkg.Image) (*ParsedPackage, error) {
 	return finalizePkg(pkg)
 }
 
+// FromStream takes a package YAML stream and returns a ParsedPackage for
+// consumption by upstream callers. Image metadata is not populated.
+func (r *Marshaler) FromStream(reader io.ReadCloser) (*ParsedPackage, error) {
+	pkg, err := r.parseYaml(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return finalizePkg(pkg)
+}
+
 // FromDir takes an afero.Fs and a path to a directory and returns a
 // ParsedPackage based on the directories contents for consumption by upstream
 // callers.
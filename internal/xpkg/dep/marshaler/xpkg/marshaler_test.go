@@ -74,7 +74,7 @@ func TestFromImage(t *testing.T) {
 						Registry: "index.docker.io",
 						Repo:     "crossplane/provider-aws",
 						Version:  "v0.20.0",
-						Digest:   "sha256:e705d37caf84ca874800fa0d838804b83759e1acff9dec1c61e20178695f3206",
+						Digest:   digest(newPackageImage(testProviderPkgYaml)),
 					},
 					Image: newPackageImage(testProviderPkgYaml),
 				},
@@ -24,24 +24,43 @@ import (
 )
 
 // LocalFetcher --
-type LocalFetcher struct{}
+type LocalFetcher struct {
+	keychain authn.Keychain
+}
+
+// FetcherOption modifies the LocalFetcher.
+type FetcherOption func(*LocalFetcher)
+
+// WithKeychain sets the keychain used to authenticate to registries. The
+// default Docker keychain is used if not set.
+func WithKeychain(kc authn.Keychain) FetcherOption {
+	return func(f *LocalFetcher) {
+		f.keychain = kc
+	}
+}
 
 // NewLocalFetcher --
-func NewLocalFetcher() *LocalFetcher {
-	return &LocalFetcher{}
+func NewLocalFetcher(opts ...FetcherOption) *LocalFetcher {
+	f := &LocalFetcher{
+		keychain: authn.DefaultKeychain,
+	}
+	for _, o := range opts {
+		o(f)
+	}
+	return f
 }
 
 // Fetch fetches a package image.
 func (r *LocalFetcher) Fetch(ctx context.Context, ref name.Reference, secrets ...string) (v1.Image, error) {
-	return remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	return remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(r.keychain))
 }
 
 // Head fetches a package descriptor.
 func (r *LocalFetcher) Head(ctx context.Context, ref name.Reference, secrets ...string) (*v1.Descriptor, error) {
-	return remote.Head(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	return remote.Head(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(r.keychain))
 }
 
 // Tags fetches a package's tags.
 func (r *LocalFetcher) Tags(ctx context.Context, ref name.Reference, secrets ...string) ([]string, error) {
-	return remote.List(ref.Context(), remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	return remote.List(ref.Context(), remote.WithContext(ctx), remote.WithAuthFromKeychain(r.keychain))
 }
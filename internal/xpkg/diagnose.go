@@ -0,0 +1,160 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+	pkgmetav1 "github.com/crossplane/crossplane/apis/pkg/meta/v1"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/upbound/up/internal/xpkg/parser/linter"
+	"github.com/upbound/up/internal/xpkg/scheme"
+)
+
+// Levels of a Diagnostic.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+)
+
+// Rules reported by Diagnose.
+const (
+	RuleMeta         = "meta"
+	RuleObject       = "object"
+	RuleDependency   = "dependency"
+	RuleDuplicateCRD = "duplicate-crd"
+	RuleAnnotation   = "annotation"
+)
+
+// MarketplaceAnnotations are the annotations on the package meta used by the
+// Upbound Marketplace to display a package.
+var MarketplaceAnnotations = []string{
+	"meta.crossplane.io/maintainer",
+	"meta.crossplane.io/source",
+	"meta.crossplane.io/license",
+	"meta.crossplane.io/description",
+	"meta.crossplane.io/readme",
+}
+
+// A Diagnostic is an issue found in a package.
+type Diagnostic struct {
+	Rule    string `json:"rule"`
+	Level   string `json:"level"`
+	Object  string `json:"object"`
+	Message string `json:"message"`
+}
+
+// Diagnose checks the supplied package and returns all issues found. Unlike
+// a linter.Linter it does not stop at the first issue.
+func Diagnose(pkg linter.Package) []Diagnostic {
+	diags := []Diagnostic{}
+	metas := pkg.GetMeta()
+	if len(metas) != 1 {
+		diags = append(diags, Diagnostic{Rule: RuleMeta, Level: LevelError, Message: errNotExactlyOneMeta})
+	}
+
+	var objLinter linter.ObjectLinterFn
+	if len(metas) == 1 {
+		m := metas[0]
+		name := objectName(m)
+		switch {
+		case IsProvider(m) == nil:
+			objLinter = linter.Or(IsCRD, IsValidatingWebhookConfiguration, IsMutatingWebhookConfiguration)
+		case IsConfiguration(m) == nil:
+			objLinter = linter.Or(IsXRD, IsComposition)
+		case IsFunction(m) == nil:
+		default:
+			diags = append(diags, Diagnostic{Rule: RuleMeta, Level: LevelError, Object: name, Message: errNotMeta})
+		}
+		diags = append(diags, diagnoseMeta(name, m)...)
+	}
+
+	owners := map[string]bool{}
+	for _, o := range pkg.GetObjects() {
+		name := objectName(o)
+		if objLinter != nil {
+			if err := objLinter(o); err != nil {
+				diags = append(diags, Diagnostic{Rule: RuleObject, Level: LevelError, Object: name, Message: err.Error()})
+			}
+		}
+		if IsCRD(o) != nil && IsXRD(o) != nil {
+			continue
+		}
+		if owners[name] {
+			diags = append(diags, Diagnostic{Rule: RuleDuplicateCRD, Level: LevelError, Object: name, Message: "type is defined more than once in the package"})
+		}
+		owners[name] = true
+	}
+	return diags
+}
+
+func diagnoseMeta(name string, o runtime.Object) []Diagnostic {
+	diags := []Diagnostic{}
+	if p, ok := scheme.TryConvertToPkg(o, &pkgmetav1.Provider{}, &pkgmetav1.Configuration{}); ok {
+		if err := PackageValidSemver(o); err != nil {
+			diags = append(diags, Diagnostic{Rule: RuleMeta, Level: LevelError, Object: name, Message: err.Error()})
+		}
+		for _, d := range p.GetDependencies() {
+			diags = append(diags, diagnoseDependency(name, d)...)
+		}
+	}
+
+	a, err := kmeta.Accessor(o)
+	if err != nil {
+		return diags
+	}
+	annotations := a.GetAnnotations()
+	for _, k := range MarketplaceAnnotations {
+		if annotations[k] == "" {
+			diags = append(diags, Diagnostic{Rule: RuleAnnotation, Level: LevelWarning, Object: name, Message: fmt.Sprintf("missing annotation %s", k)})
+		}
+	}
+	return diags
+}
+
+func diagnoseDependency(name string, d pkgmetav1.Dependency) []Diagnostic {
+	var pkg string
+	switch {
+	case d.Provider != nil && d.Configuration == nil:
+		pkg = *d.Provider
+	case d.Configuration != nil && d.Provider == nil:
+		pkg = *d.Configuration
+	default:
+		return []Diagnostic{{Rule: RuleDependency, Level: LevelError, Object: name, Message: "dependency must specify exactly one of provider or configuration"}}
+	}
+	diags := []Diagnostic{}
+	if _, err := ValidDep(pkg); err != nil {
+		diags = append(diags, Diagnostic{Rule: RuleDependency, Level: LevelError, Object: name, Message: fmt.Sprintf("%s: %s", pkg, err)})
+	}
+	if _, err := semver.NewConstraint(d.Version); err != nil {
+		diags = append(diags, Diagnostic{Rule: RuleDependency, Level: LevelError, Object: name, Message: fmt.Sprintf("%s: invalid version constraint %q: %s", pkg, d.Version, err)})
+	}
+	return diags
+}
+
+func objectName(o runtime.Object) string {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	a, err := kmeta.Accessor(o)
+	if err != nil {
+		return kind
+	}
+	if kind == "" {
+		return a.GetName()
+	}
+	return fmt.Sprintf("%s/%s", kind, a.GetName())
+}
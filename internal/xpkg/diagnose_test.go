@@ -0,0 +1,125 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/upbound/up/internal/xpkg/parser/yaml"
+)
+
+const annotatedConfMeta = `apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: getting-started
+  annotations:
+    meta.crossplane.io/maintainer: Upbound <support@upbound.io>
+    meta.crossplane.io/source: github.com/upbound/getting-started
+    meta.crossplane.io/license: Apache-2.0
+    meta.crossplane.io/description: Getting started.
+    meta.crossplane.io/readme: Getting started.
+spec:
+  crossplane:
+    version: ">=v1.12.0"
+  dependsOn:
+  - provider: xpkg.upbound.io/upbound/provider-aws
+    version: ">=v0.30.0"
+`
+
+const xrd = `apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xbuckets.example.org
+spec:
+  group: example.org
+  names:
+    kind: XBucket
+    plural: xbuckets
+  versions:
+  - name: v1alpha1
+    served: true
+    referenceable: true
+`
+
+func TestDiagnose(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		stream string
+		want   []string
+	}{
+		"Valid": {
+			reason: "A valid package should not have diagnostics.",
+			stream: annotatedConfMeta + "---\n" + xrd,
+			want:   []string{},
+		},
+		"AllIssues": {
+			reason: "All issues in a package should be reported.",
+			stream: `apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: getting-started
+  annotations:
+    meta.crossplane.io/maintainer: Upbound <support@upbound.io>
+    meta.crossplane.io/source: github.com/upbound/getting-started
+    meta.crossplane.io/license: Apache-2.0
+    meta.crossplane.io/description: Getting started.
+spec:
+  crossplane:
+    version: "not-a-constraint"
+  dependsOn:
+  - provider: xpkg.upbound.io/upbound/provider-aws
+    version: "latest"
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: buckets.example.org
+---
+` + xrd + "---\n" + xrd,
+			want: []string{
+				"error meta Configuration/getting-started",
+				"error dependency Configuration/getting-started",
+				"warning annotation Configuration/getting-started",
+				"error object CustomResourceDefinition/buckets.example.org",
+				"error duplicate-crd CompositeResourceDefinition/xbuckets.example.org",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := yaml.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg, err := p.Parse(context.Background(), io.NopCloser(strings.NewReader(tc.stream)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			diags := Diagnose(pkg)
+			got := make([]string, len(diags))
+			for i, d := range diags {
+				got[i] = strings.Join([]string{d.Level, d.Rule, d.Object}, " ")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiagnose(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -29,13 +30,18 @@ import (
 // Layer creates a v1.Layer that represetns the layer contents for the xpkg and
 // adds a corresponding label to the image Config for the layer.
 func Layer(r io.Reader, fileName, annotation string, fileSize int64, mode os.FileMode, cfg *v1.Config) (v1.Layer, error) {
+	return layer(r, fileName, annotation, fileSize, mode, time.Time{}, cfg)
+}
+
+func layer(r io.Reader, fileName, annotation string, fileSize int64, mode os.FileMode, modTime time.Time, cfg *v1.Config) (v1.Layer, error) {
 	tarBuf := new(bytes.Buffer)
 	tw := tar.NewWriter(tarBuf)
 
 	exHdr := &tar.Header{
-		Name: fileName,
-		Mode: int64(mode),
-		Size: fileSize,
+		Name:    fileName,
+		Mode:    int64(mode),
+		Size:    fileSize,
+		ModTime: modTime,
 	}
 
 	if err := writeLayer(tw, exHdr, r); err != nil {
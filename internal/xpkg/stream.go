@@ -0,0 +1,92 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"archive/tar"
+	"io"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+)
+
+const (
+	errGetManifest             = "failed to get package image manifest"
+	errFetchLayer              = "failed to fetch annotated base layer"
+	errGetUncompressed         = "failed to get uncompressed contents from layer"
+	errMultipleAnnotatedLayers = "package is invalid due to multiple annotated base layers"
+	errOpenPackageStream       = "failed to open package stream file"
+)
+
+// PackageStream returns the package YAML stream of the supplied image. If the
+// image has a layer annotated as the xpkg base layer only that layer is
+// fetched, otherwise the image filesystem is flattened.
+func PackageStream(img v1.Image) (io.ReadCloser, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, errors.Wrap(err, errGetManifest)
+	}
+
+	var layer v1.Layer
+	for _, l := range manifest.Layers {
+		if a, ok := l.Annotations[AnnotationKey]; !ok || a != PackageAnnotation {
+			continue
+		}
+		// NOTE(hasheddan): the xpkg specification dictates that only one
+		// layer descriptor may be annotated as xpkg base.
+		if layer != nil {
+			return nil, errors.New(errMultipleAnnotatedLayers)
+		}
+		layer, err = img.LayerByDigest(l.Digest)
+		if err != nil {
+			return nil, errors.Wrap(err, errFetchLayer)
+		}
+	}
+
+	var tarc io.ReadCloser
+	if layer != nil {
+		tarc, err = layer.Uncompressed()
+		if err != nil {
+			return nil, errors.Wrap(err, errGetUncompressed)
+		}
+	} else {
+		tarc = mutate.Extract(img)
+	}
+
+	t := tar.NewReader(tarc)
+	for {
+		h, err := t.Next()
+		if err != nil {
+			_ = tarc.Close()
+			return nil, errors.Wrap(err, errOpenPackageStream)
+		}
+		if h.Name == StreamFile {
+			return &streamReader{Reader: io.LimitReader(t, h.Size), closer: tarc}, nil
+		}
+	}
+}
+
+// streamReader reads a single file from a tarball and closes the underlying
+// tarball when closed.
+type streamReader struct {
+	io.Reader
+	closer io.Closer
+}
+
+// Close closes the underlying tarball.
+func (s *streamReader) Close() error {
+	return s.closer.Close()
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xpkg
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+func TestPackageStream(t *testing.T) {
+	randLayer, _ := random.Layer(int64(1000), types.DockerLayer)
+	annotations := map[string]string{AnnotationKey: PackageAnnotation}
+	randImg, _ := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       randLayer,
+		Annotations: annotations,
+	})
+	randImgDup, _ := mutate.Append(randImg, mutate.Addendum{
+		Layer:       randLayer,
+		Annotations: annotations,
+	})
+
+	streamCont := "somestreamofyaml"
+	tarBuf := new(bytes.Buffer)
+	tw := tar.NewWriter(tarBuf)
+	_ = tw.WriteHeader(&tar.Header{
+		Name: StreamFile,
+		Mode: int64(StreamFileMode),
+		Size: int64(len(streamCont)),
+	})
+	_, _ = tw.Write([]byte(streamCont))
+	_ = tw.Close()
+	packLayer, _ := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(tarBuf.Bytes())), nil
+	})
+	packImg, _ := mutate.AppendLayers(empty.Image, packLayer)
+	annotatedImg, _ := mutate.Append(empty.Image,
+		mutate.Addendum{Layer: randLayer},
+		mutate.Addendum{Layer: packLayer, Annotations: annotations},
+	)
+
+	type want struct {
+		content string
+		err     error
+	}
+	cases := map[string]struct {
+		reason string
+		img    v1.Image
+		want   want
+	}{
+		"ErrorMultipleAnnotatedLayers": {
+			reason: "Should return error if manifest contains multiple annotated layers.",
+			img:    randImgDup,
+			want: want{
+				err: errors.New(errMultipleAnnotatedLayers),
+			},
+		},
+		"ErrorNoStream": {
+			reason: "Should return error if image contents do not have package.yaml.",
+			img:    randImg,
+			want: want{
+				err: errors.Wrap(io.EOF, errOpenPackageStream),
+			},
+		},
+		"SuccessFlattened": {
+			reason: "Should return the package stream from the flattened filesystem if no layer is annotated.",
+			img:    packImg,
+			want: want{
+				content: streamCont,
+			},
+		},
+		"SuccessAnnotated": {
+			reason: "Should return the package stream from the annotated base layer.",
+			img:    annotatedImg,
+			want: want{
+				content: streamCont,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rc, err := PackageStream(tc.img)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nPackageStream(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			defer rc.Close() //nolint:errcheck
+			b, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.content, string(b)); diff != "" {
+				t.Errorf("\n%s\nPackageStream(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}